@@ -1,9 +1,12 @@
 package aggregator
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
@@ -13,6 +16,11 @@ import (
 	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
 	"github.com/Layr-Labs/eigensdk-go/types"
 	operatorrequester "github.com/Layr-Labs/teal/aggregator/operator_requester"
+	"github.com/Layr-Labs/teal/aggregator/store"
+	"github.com/Layr-Labs/teal/common"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type AggregatorService struct {
@@ -20,26 +28,77 @@ type AggregatorService struct {
 	avsRegistryReader avsregistry.AvsRegistryService
 	blsAggService     blsagg.BlsAggregationService
 	operatorRequester operatorrequester.OperatorRequester
+	certificateStore  store.CertificateStore
+	taskRegistry      store.TaskRegistry
+	defaultPolicy     TaskPolicy
+	operatorTimeout   time.Duration
+	selectionStrategy SelectionStrategy
+	secondWaveDelay   time.Duration
+	// hashFunction must match the one of blsAggService, it is used to report the
+	// digests signed by operators
+	hashFunction types.TaskResponseHashFunction
 
-	mu sync.Mutex
+	divergenceHandler    DivergenceHandler
+	minLeadingStakeShare float64
+
+	metrics *Metrics
+	tracer  trace.Tracer
+
+	// goroutines tracks the goroutines of tasks, which can outlive them until their
+	// requests observe the cancellation
+	goroutines sync.WaitGroup
+
+	// responseChans routes responses from the shared blsagg response channel
+	// to the GetCertificate call waiting for that task index
+	responseChans   map[types.TaskIndex]chan blsagg.BlsAggregationServiceResponse
+	responseChansMu sync.Mutex
 }
 
+// NewAggregatorService creates an aggregator whose operators sign the digests of domain,
+// blsAggService must be created with domain.HashFn(). See WithLegacyDigest for operators
+// signing keccak256(response).
 func NewAggregatorService(
 	logger logging.Logger,
 	avsRegistryReader avsregistry.AvsRegistryService,
 	blsAggService blsagg.BlsAggregationService,
 	operatorRequester operatorrequester.OperatorRequester,
+	domain common.SigningDomain,
+	opts ...Option,
 ) *AggregatorService {
-	return &AggregatorService{
+	s := &AggregatorService{
 		logger:            logger,
 		avsRegistryReader: avsRegistryReader,
 		blsAggService:     blsAggService,
 		operatorRequester: operatorRequester,
+		taskRegistry:      store.NewInMemoryTaskRegistry(),
+		defaultPolicy:     DefaultTaskPolicy,
+		hashFunction:      domain.HashFn(),
+		responseChans:     make(map[types.TaskIndex]chan blsagg.BlsAggregationServiceResponse),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.tracer == nil {
+		s.tracer = otel.Tracer(TracerName)
 	}
+	go s.routeResponses()
+	return s
+}
+
+// Wait blocks until the goroutines of the tasks that completed have exited. They are
+// canceled when their task completes, but a request to an operator may take a moment
+// to observe it.
+func (s *AggregatorService) Wait() {
+	s.goroutines.Wait()
+}
+
+// CertificateStore returns the store certificates are persisted to, or nil if none was configured
+func (s *AggregatorService) CertificateStore() store.CertificateStore {
+	return s.certificateStore
 }
 
 // GetCertificate sends a task to all registered nodes and aggregates their responses
-// Only works for single quorum for simplicity
+// for a single quorum. See GetMultiQuorumCertificate for certificates spanning several quorums.
 func (s *AggregatorService) GetCertificate(
 	ctx context.Context,
 	taskIndex types.TaskIndex,
@@ -48,80 +107,566 @@ func (s *AggregatorService) GetCertificate(
 	quorumThresholdPercentage types.QuorumThresholdPercentage,
 	data []byte,
 	timeToExpiry time.Duration,
-) (*blsagg.BlsAggregationServiceResponse, error) {
-	// Only allow one task at a time
-	s.mu.Lock()
-	defer s.mu.Unlock()
-
-	quorumNumbers := types.QuorumNums{quorumNumber}
-	quorumThresholdPercentages := types.QuorumThresholdPercentages{quorumThresholdPercentage}
-
-	// Initialize task in BLS aggregation service
-	err := s.blsAggService.InitializeNewTaskWithWindow(
+) (*TaskResult, error) {
+	return s.GetMultiQuorumCertificate(
+		ctx,
 		taskIndex,
 		taskCreatedBlock,
-		quorumNumbers,
-		quorumThresholdPercentages,
+		types.QuorumNums{quorumNumber},
+		types.QuorumThresholdPercentages{quorumThresholdPercentage},
+		data,
 		timeToExpiry,
-		1*time.Second,
 	)
+}
+
+// GetMultiQuorumCertificate sends a task to the union of the operators registered in
+// quorumNumbers and aggregates their responses. The certificate is only produced once
+// the signers hold at least quorumThresholdPercentages[i] of the stake of quorumNumbers[i]
+// for every quorum. quorumNumbers must be in ascending order, as required on-chain by
+// BLSSignatureChecker.checkSignatures.
+//
+// Once the operators were contacted, the result is returned even if the certificate
+// could not be produced, so that the caller can tell what each operator did.
+func (s *AggregatorService) GetMultiQuorumCertificate(
+	ctx context.Context,
+	taskIndex types.TaskIndex,
+	taskCreatedBlock uint32,
+	quorumNumbers types.QuorumNums,
+	quorumThresholdPercentages types.QuorumThresholdPercentages,
+	data []byte,
+	timeToExpiry time.Duration,
+) (*TaskResult, error) {
+	return s.certify(ctx, &Task{
+		TaskIndex: taskIndex,
+		TaskRequest: TaskRequest{
+			ReferenceBlockNumber:       taskCreatedBlock,
+			QuorumNumbers:              quorumNumbers,
+			QuorumThresholdPercentages: quorumThresholdPercentages,
+			Data:                       data,
+			TimeToExpiry:               timeToExpiry,
+		},
+	})
+}
+
+// certify sends task to all operators of its quorums and aggregates their responses
+// according to the task's policy
+func (s *AggregatorService) certify(ctx context.Context, task *Task) (*TaskResult, error) {
+	ctx, span := s.tracer.Start(ctx, "GetCertificate", taskAttributes(task))
+	defer s.metrics.taskStarted()()
+	result, err := s.aggregate(ctx, task, time.Now())
+	s.metrics.observeTask(task, result, err)
+	endSpan(span, err)
+	return result, err
+}
+
+// aggregate certifies task, which was started at start
+func (s *AggregatorService) aggregate(ctx context.Context, task *Task, start time.Time) (*TaskResult, error) {
+	if err := ValidateQuorums(task.QuorumNumbers, task.QuorumThresholdPercentages); err != nil {
+		return nil, err
+	}
+	policy := s.defaultPolicy
+	if task.Policy != nil {
+		policy = *task.Policy
+	}
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid task policy: %w", err)
+	}
+	if err := policy.ValidateExpiry(task.TimeToExpiry); err != nil {
+		return nil, fmt.Errorf("invalid task policy: %w", err)
+	}
+	if err := ValidateOperatorTimeout(task.OperatorTimeout, task.TimeToExpiry); err != nil {
+		return nil, err
+	}
+	operatorTimeout := s.operatorTimeout
+	if task.OperatorTimeout != 0 {
+		operatorTimeout = task.OperatorTimeout
+	}
+
+	// Register the task before initializing it so that its response can't be missed
+	responseC, err := s.registerTask(task.TaskIndex)
+	if err != nil {
+		return nil, err
+	}
+	defer s.unregisterTask(task.TaskIndex)
+
+	// Initialize task in BLS aggregation service
+	_, span := s.tracer.Start(ctx, "InitializeNewTask")
+	err = s.blsAggService.InitializeNewTaskWithWindow(
+		task.TaskIndex,
+		task.ReferenceBlockNumber,
+		task.QuorumNumbers,
+		task.QuorumThresholdPercentages,
+		task.TimeToExpiry,
+		policy.aggregationWindow(),
+	)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize task: %w", err)
 	}
 
 	// Get operators from registry
-	operators, err := s.avsRegistryReader.GetOperatorsAvsStateAtBlock(ctx, quorumNumbers, taskCreatedBlock)
+	registryCtx, span := s.tracer.Start(ctx, "GetOperatorsAvsStateAtBlock")
+	operators, err := s.avsRegistryReader.GetOperatorsAvsStateAtBlock(registryCtx, task.QuorumNumbers, task.ReferenceBlockNumber)
+	endSpan(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get operators: %w", err)
 	}
 
-	// Send task to all operators in parallel
-	for operatorId, operator := range operators {
-		go func(operatorId types.OperatorId, operator types.OperatorAvsState) {
-			s.logger.Info("Requesting certification from operator", "operatorId", operatorId, "socket", operator.OperatorInfo.Socket)
-			// Create connection for this operator
-			resp, err := s.operatorRequester.RequestCertification(ctx, operator, taskIndex, data)
-			if err != nil {
-				return
-			}
+	registryCtx, span = s.tracer.Start(ctx, "GetQuorumsAvsStateAtBlock")
+	quorums, err := s.avsRegistryReader.GetQuorumsAvsStateAtBlock(registryCtx, task.QuorumNumbers, task.ReferenceBlockNumber)
+	endSpan(span, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorums: %w", err)
+	}
+
+	outcomes := newOutcomeCollector(operators)
+	responses := newResponseTally(task, operators, quorums)
+	firstWave, secondWave := splitWaves(s.selectionStrategy, task, operators, quorums)
+	for _, operatorId := range secondWave {
+		outcomes.record(operatorId, OutcomeNotContacted, 0, nil, types.TaskResponseDigest{})
+	}
+	// The task owns its requests: they, including their retries, are canceled once the
+	// task completes or expires, and the signatures still arriving afterwards are dropped
+	taskCtx, cancelTask := context.WithTimeout(ctx, task.TimeToExpiry)
+	defer func() {
+		// cancel first, the signature being processed may wait on blsagg's task goroutine,
+		// which exits once the task completed
+		cancelTask()
+		responses.complete()
+	}()
+	// In WaitForAllResponsive mode signatures are held back until every operator answered,
+	// so that the thresholds can't be met before all of them are aggregated
+	signatures := newSignatureBuffer(policy.Mode == WaitForAllResponsive, func(signature operatorSignature) {
+		err := responses.submit(
+			signature,
+			func(signature operatorSignature) error {
+				return s.processSignature(taskCtx, task, signature)
+			},
+			func(err error) {
+				class := OutcomeSigned
+				if err != nil {
+					class = OutcomeRejectedSignature
+				}
+				outcomes.record(signature.operatorId, class, signature.latency, err, signature.digest)
+			},
+		)
+		if errors.Is(err, errTaskCompleted) {
+			s.logger.Debug("Dropping signature received after the task completed",
+				"taskIndex", task.TaskIndex,
+				"operatorId", signature.operatorId)
+			s.metrics.lateResultDropped()
+		}
+	})
+
+	requestCtx, fanOutSpan := s.tracer.Start(taskCtx, "RequestOperators", trace.WithAttributes(
+		attribute.Int("teal.operators", len(operators)),
+		attribute.Int("teal.first_wave_operators", len(firstWave)),
+	))
+	var requests sync.WaitGroup
+	// sendRequests requests the signatures of operatorIds, the returned wait group is done
+	// once all of them answered or failed
+	sendRequests := func(operatorIds []types.OperatorId) *sync.WaitGroup {
+		var wave sync.WaitGroup
+		for _, operatorId := range operatorIds {
+			requests.Add(1)
+			wave.Add(1)
+			s.goroutines.Add(1)
+			go func(operatorId types.OperatorId, operator types.OperatorAvsState) {
+				defer s.goroutines.Done()
+				defer requests.Done()
+				defer wave.Done()
+				s.requestOperator(requestCtx, task, operatorId, operator, operatorTimeout, outcomes, signatures)
+			}(operatorId, operators[operatorId])
+		}
+		return &wave
+	}
+
+	firstWaveRequests := sendRequests(firstWave)
+	firstWaveDone := make(chan struct{})
+	s.goroutines.Add(1)
+	go func() {
+		defer s.goroutines.Done()
+		firstWaveRequests.Wait()
+		close(firstWaveDone)
+	}()
 
-			signature := &bls.Signature{G1Point: bls.NewG1Point(big.NewInt(0), big.NewInt(0))}
-			_, err = signature.SetBytes(resp.Signature)
-			if err != nil {
-				s.logger.Error("Failed to unmarshal signature",
-					"operatorId", operatorId,
-					"error", err)
+	if len(secondWave) > 0 {
+		// the second wave may still add requests until it was decided on
+		requests.Add(1)
+		s.goroutines.Add(1)
+		go func() {
+			defer s.goroutines.Done()
+			defer requests.Done()
+			if !s.awaitSecondWave(taskCtx, firstWaveDone, responses, signatures, policy.Mode == WaitForAllResponsive) {
 				return
 			}
+			s.logger.Info("Thresholds not met by the first wave, contacting the remaining operators",
+				"taskIndex", task.TaskIndex,
+				"operators", len(secondWave))
+			fanOutSpan.AddEvent("second wave", trace.WithAttributes(attribute.Int("teal.operators", len(secondWave))))
+			for _, operatorId := range secondWave {
+				outcomes.record(operatorId, OutcomePending, 0, nil, types.TaskResponseDigest{})
+			}
+			sendRequests(secondWave)
+		}()
+	}
 
-			s.logger.Info("Received signature from operator", "operatorId", operatorId)
-
-			// Process signature from node
-			err = s.blsAggService.ProcessNewSignature(
-				ctx,
-				taskIndex,
-				types.TaskResponse(resp.Data),
-				signature,
-				operatorId,
-			)
-			if err != nil {
-				s.logger.Error("Failed to process signature",
-					"operatorId", operatorId,
-					"error", err)
+	requestsDone := make(chan struct{})
+	s.goroutines.Add(1)
+	go func() {
+		defer s.goroutines.Done()
+		requests.Wait()
+		fanOutSpan.End()
+		close(requestsDone)
+	}()
+
+	if policy.Mode == WaitForAllResponsive {
+		s.goroutines.Add(1)
+		go func() {
+			defer s.goroutines.Done()
+			// leave the window to aggregate whatever was collected before the task expires
+			flushTimer := time.NewTimer(task.TimeToExpiry - policy.Window)
+			defer flushTimer.Stop()
+			select {
+			case <-requestsDone:
+			case <-flushTimer.C:
+			case <-taskCtx.Done():
 				return
 			}
-			s.logger.Info("Processed signature from operator", "operatorId", operatorId)
-		}(operatorId, operator)
+			signatures.flush()
+		}()
 	}
 
 	// Wait for aggregated response
+	_, span = s.tracer.Start(ctx, "WaitForAggregation")
+	defer span.End()
 	select {
-	case resp := <-s.blsAggService.GetResponseChannel():
+	case resp := <-responseC:
 		if resp.Err != nil {
-			return nil, fmt.Errorf("aggregation failed: %w", resp.Err)
+			return s.taskResult(task, nil, outcomes, operators), fmt.Errorf("aggregation failed: %w", resp.Err)
+		}
+		// downstream consumers work with the response bytes the operators signed
+		if taskResponse, ok := resp.TaskResponse.(common.TaskResponse); ok {
+			resp.TaskResponse = taskResponse.Data
+		}
+		// blsagg answers as soon as it aggregated the signature that met the thresholds,
+		// wait until the tally, and with it the outcome of that signature, caught up
+		select {
+		case <-responses.thresholdsMetSignal():
+		case <-taskCtx.Done():
+		}
+		if thresholds := responses.thresholdsMetSnapshot(); thresholds != nil && thresholds.digest == resp.TaskResponseDigest {
+			s.metrics.observeThreshold(thresholds.metAt.Sub(start), thresholds.signedStakePercentages)
 		}
-		return &resp, nil
+		s.storeCertificate(ctx, &resp, task, operators)
+		return s.taskResult(task, &resp, outcomes, operators), nil
 	case <-ctx.Done():
-		return nil, ctx.Err()
+		return s.taskResult(task, nil, outcomes, operators), ctx.Err()
+	}
+}
+
+// requestOperator requests the signature of operator for task, which is bounded by timeout
+// if it is not 0, and records its outcome or hands its signature to signatures
+func (s *AggregatorService) requestOperator(
+	ctx context.Context,
+	task *Task,
+	operatorId types.OperatorId,
+	operator types.OperatorAvsState,
+	timeout time.Duration,
+	outcomes *outcomeCollector,
+	signatures *signatureBuffer,
+) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	signature, err := s.requestSignature(ctx, task, operator)
+	if err != nil {
+		outcomes.record(operatorId, classifyRequestError(err), time.Since(start), err, types.TaskResponseDigest{})
+		return
+	}
+	signatures.add(*signature)
+}
+
+// awaitSecondWave reports whether the operators left out of the first wave must be contacted,
+// which is the case if the thresholds were not met once the first wave answered or the second
+// wave delay passed. If hold is set, the held signatures of the first wave are aggregated first.
+func (s *AggregatorService) awaitSecondWave(
+	ctx context.Context,
+	firstWaveDone <-chan struct{},
+	responses *responseTally,
+	signatures *signatureBuffer,
+	hold bool,
+) bool {
+	var delay <-chan time.Time
+	if s.secondWaveDelay > 0 {
+		timer := time.NewTimer(s.secondWaveDelay)
+		defer timer.Stop()
+		delay = timer.C
+	}
+
+	select {
+	case <-firstWaveDone:
+		if hold {
+			signatures.flush()
+		}
+	case <-delay:
+	case <-responses.thresholdsMetSignal():
+		return false
+	case <-ctx.Done():
+		return false
+	}
+
+	select {
+	case <-responses.thresholdsMetSignal():
+		return false
+	default:
+		return ctx.Err() == nil
+	}
+}
+
+// taskResult assembles the result of a task and reports diverging responses
+func (s *AggregatorService) taskResult(
+	task *Task,
+	resp *blsagg.BlsAggregationServiceResponse,
+	outcomes *outcomeCollector,
+	operators map[types.OperatorId]types.OperatorAvsState,
+) *TaskResult {
+	result := &TaskResult{
+		BlsAggregationServiceResponse: resp,
+		Operators:                     outcomes.snapshot(resp),
+	}
+	result.Divergence = summarizeDivergence(task.TaskIndex, task.QuorumNumbers, result.Operators, operators)
+
+	if result.Divergence.Diverged() {
+		s.logger.Warn("Operators signed diverging responses",
+			"taskIndex", task.TaskIndex,
+			"responses", len(result.Divergence.Responses),
+			"leadingStakeShare", result.Divergence.LeadingStakeShare())
+	}
+	if s.divergenceHandler != nil && len(result.Divergence.Responses) > 0 &&
+		result.Divergence.LeadingStakeShare() < s.minLeadingStakeShare {
+		s.divergenceHandler(result.Divergence)
+	}
+	return result
+}
+
+// errMalformedSignature is returned by requestSignature if the operator's signature can't be decoded
+var errMalformedSignature = errors.New("malformed signature")
+
+// operatorSignature is a response of an operator whose signature was decoded but not yet verified
+type operatorSignature struct {
+	operatorId types.OperatorId
+	response   []byte
+	signature  *bls.Signature
+	// digest is the digest of response, which the signature is expected to sign
+	digest types.TaskResponseDigest
+	// latency is the time the operator took to answer
+	latency time.Duration
+}
+
+// requestSignature requests the certification of task from operator and decodes its signature
+func (s *AggregatorService) requestSignature(
+	ctx context.Context,
+	task *Task,
+	operator types.OperatorAvsState,
+) (*operatorSignature, error) {
+	operatorId := operator.OperatorId
+	s.logger.Info("Requesting certification from operator", "operatorId", operatorId, "socket", operator.OperatorInfo.Socket)
+	ctx, span := s.tracer.Start(ctx, "RequestCertification", trace.WithAttributes(
+		operatorAttribute(operatorId),
+		attribute.String("teal.operator_socket", operator.OperatorInfo.Socket.String()),
+	))
+	start := time.Now()
+	resp, err := s.operatorRequester.RequestCertification(ctx, operator, task.TaskIndex, task.ReferenceBlockNumber, task.Data)
+	latency := time.Since(start)
+	endSpan(span, err)
+	if err != nil {
+		s.logger.Error("Failed to request certification",
+			"operatorId", operatorId,
+			"error", err)
+		return nil, err
+	}
+
+	signature := &bls.Signature{G1Point: bls.NewG1Point(big.NewInt(0), big.NewInt(0))}
+	_, err = signature.SetBytes(resp.Signature)
+	if err != nil {
+		s.logger.Error("Failed to unmarshal signature",
+			"operatorId", operatorId,
+			"error", err)
+		return nil, fmt.Errorf("%w: %v", errMalformedSignature, err)
+	}
+
+	digest, err := s.hashFunction(taskResponse(task, resp.Data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash response: %w", err)
+	}
+
+	s.logger.Info("Received signature from operator", "operatorId", operatorId)
+	return &operatorSignature{
+		operatorId: operatorId,
+		response:   resp.Data,
+		signature:  signature,
+		digest:     digest,
+		latency:    latency,
+	}, nil
+}
+
+// processSignature hands a signature to the bls aggregation service, which verifies and aggregates it
+func (s *AggregatorService) processSignature(ctx context.Context, task *Task, signature operatorSignature) error {
+	ctx, span := s.tracer.Start(ctx, "ProcessNewSignature", trace.WithAttributes(operatorAttribute(signature.operatorId)))
+	err := s.blsAggService.ProcessNewSignature(
+		ctx,
+		task.TaskIndex,
+		taskResponse(task, signature.response),
+		signature.signature,
+		signature.operatorId,
+	)
+	endSpan(span, err)
+	if err != nil {
+		s.logger.Error("Failed to process signature",
+			"operatorId", signature.operatorId,
+			"error", err)
+		return err
+	}
+	s.logger.Info("Processed signature from operator", "operatorId", signature.operatorId)
+	return nil
+}
+
+// taskResponse binds response to task, so that the hash function can compute a
+// domain separated digest, see common.SigningDomain
+func taskResponse(task *Task, response []byte) common.TaskResponse {
+	return common.TaskResponse{
+		TaskIndex:            uint32(task.TaskIndex),
+		ReferenceBlockNumber: uint32(task.ReferenceBlockNumber),
+		Data:                 response,
+	}
+}
+
+// storeCertificate persists a certificate if a store is configured. Failing to store
+// it is logged but not returned, so that the caller still receives the certificate.
+func (s *AggregatorService) storeCertificate(
+	ctx context.Context,
+	resp *blsagg.BlsAggregationServiceResponse,
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+) {
+	if s.certificateStore == nil {
+		return
+	}
+
+	nonSignerPubkeys := make(map[string]bool, len(resp.NonSignersPubkeysG1))
+	for _, pubkey := range resp.NonSignersPubkeysG1 {
+		nonSignerPubkeys[string(pubkey.Serialize())] = true
+	}
+	signers := []types.OperatorId{}
+	nonSigners := []types.OperatorId{}
+	for operatorId, operator := range operators {
+		if nonSignerPubkeys[string(operator.OperatorInfo.Pubkeys.G1Pubkey.Serialize())] {
+			nonSigners = append(nonSigners, operatorId)
+		} else {
+			signers = append(signers, operatorId)
+		}
+	}
+
+	sortOperatorIds(signers)
+	sortOperatorIds(nonSigners)
+
+	err := s.certificateStore.Put(ctx, &store.Certificate{
+		TaskIndex:                  resp.TaskIndex,
+		ReferenceBlockNumber:       task.ReferenceBlockNumber,
+		QuorumNumbers:              task.QuorumNumbers,
+		QuorumThresholdPercentages: task.QuorumThresholdPercentages,
+		RequestData:                task.Data,
+		Response:                   resp,
+		Signers:                    signers,
+		NonSigners:                 nonSigners,
+		CreatedAt:                  time.Now(),
+	})
+	if err != nil {
+		s.logger.Error("Failed to store certificate", "taskIndex", resp.TaskIndex, "error", err)
+	}
+}
+
+func sortOperatorIds(operatorIds []types.OperatorId) {
+	sort.Slice(operatorIds, func(i, j int) bool {
+		return bytes.Compare(operatorIds[i][:], operatorIds[j][:]) < 0
+	})
+}
+
+// ValidateOperatorTimeout checks that a task's operator timeout ends before the task expires,
+// 0 leaves the operator timeout of the service in place
+func ValidateOperatorTimeout(operatorTimeout, timeToExpiry time.Duration) error {
+	if operatorTimeout < 0 {
+		return errors.New("operator timeout must not be negative")
+	}
+	if operatorTimeout != 0 && operatorTimeout >= timeToExpiry {
+		return fmt.Errorf("operator timeout %s must be shorter than the time to expiry %s", operatorTimeout, timeToExpiry)
+	}
+	return nil
+}
+
+// ValidateQuorums checks that the quorum parameters describe a certificate that can be verified on-chain
+func ValidateQuorums(quorumNumbers types.QuorumNums, quorumThresholdPercentages types.QuorumThresholdPercentages) error {
+	if len(quorumNumbers) == 0 {
+		return fmt.Errorf("no quorum numbers provided")
+	}
+	if len(quorumNumbers) != len(quorumThresholdPercentages) {
+		return fmt.Errorf("got %d quorum numbers but %d threshold percentages", len(quorumNumbers), len(quorumThresholdPercentages))
+	}
+	for i, quorumThresholdPercentage := range quorumThresholdPercentages {
+		if quorumThresholdPercentage > 100 {
+			return fmt.Errorf("threshold percentage %d of quorum %d exceeds 100", quorumThresholdPercentage, quorumNumbers[i])
+		}
+		if i > 0 && quorumNumbers[i] <= quorumNumbers[i-1] {
+			return fmt.Errorf("quorum numbers must be unique and in ascending order")
+		}
+	}
+	return nil
+}
+
+// registerTask creates the channel on which the response for taskIndex is delivered
+func (s *AggregatorService) registerTask(taskIndex types.TaskIndex) (<-chan blsagg.BlsAggregationServiceResponse, error) {
+	s.responseChansMu.Lock()
+	defer s.responseChansMu.Unlock()
+
+	if _, ok := s.responseChans[taskIndex]; ok {
+		return nil, fmt.Errorf("task %d is already in flight", taskIndex)
+	}
+	// buffered so that routeResponses never blocks on a caller that stopped waiting
+	responseC := make(chan blsagg.BlsAggregationServiceResponse, 1)
+	s.responseChans[taskIndex] = responseC
+	return responseC, nil
+}
+
+func (s *AggregatorService) unregisterTask(taskIndex types.TaskIndex) {
+	s.responseChansMu.Lock()
+	defer s.responseChansMu.Unlock()
+
+	delete(s.responseChans, taskIndex)
+}
+
+// routeResponses drains the shared blsagg response channel and forwards each
+// response to the caller waiting for its task index. Responses for tasks that
+// nobody waits for anymore are dropped.
+func (s *AggregatorService) routeResponses() {
+	for resp := range s.blsAggService.GetResponseChannel() {
+		s.responseChansMu.Lock()
+		responseC, ok := s.responseChans[resp.TaskIndex]
+		s.responseChansMu.Unlock()
+		if !ok {
+			s.logger.Debug("Dropping response for unknown task", "taskIndex", resp.TaskIndex)
+			continue
+		}
+
+		// blsagg can send a second (expiry) response for a task that already
+		// produced a certificate; only the first one is kept
+		select {
+		case responseC <- resp:
+		default:
+			s.logger.Debug("Dropping additional response for task", "taskIndex", resp.TaskIndex)
+		}
 	}
 }
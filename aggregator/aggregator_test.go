@@ -2,6 +2,8 @@ package aggregator_test
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"math/big"
 	"testing"
 	"time"
@@ -13,10 +15,20 @@ import (
 	"github.com/Layr-Labs/eigensdk-go/types"
 	"github.com/Layr-Labs/teal/aggregator"
 	mockOperatorRequester "github.com/Layr-Labs/teal/aggregator/operator_requester/mocks"
+	"github.com/Layr-Labs/teal/aggregator/store"
 	pb "github.com/Layr-Labs/teal/api/service/v1"
 	"github.com/Layr-Labs/teal/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/goleak"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func TestAggregatorService(t *testing.T) {
@@ -46,14 +58,14 @@ func TestAggregatorService(t *testing.T) {
 
 		for _, operator := range operators {
 			responseData := []byte("test 1")
-			taskResponseDigest, _ := common.Keccak256HashFn(responseData)
-			fakeOperatorRequester.EXPECT().RequestCertification(ctx, operator, taskIndex, requestData).Return(&pb.CertifyResponse{
+			taskResponseDigest := testDomain.Digest(uint32(taskIndex), blockNum, responseData)
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operator, taskIndex, blockNum, requestData).Return(&pb.CertifyResponse{
 				Signature: testOperator1.BlsKeypair.SignMessage(taskResponseDigest).Marshal(),
 				Data:      responseData,
 			}, nil)
 		}
 
-		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, common.Keccak256HashFn, logger)
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
 
 		// Create aggregator service
 		aggregatorService := aggregator.NewAggregatorService(
@@ -61,6 +73,7 @@ func TestAggregatorService(t *testing.T) {
 			fakeAvsRegistryService,
 			blsAggService,
 			fakeOperatorRequester,
+			testDomain,
 		)
 
 		resp, err := aggregatorService.GetCertificate(
@@ -79,6 +92,1072 @@ func TestAggregatorService(t *testing.T) {
 		assert.Equal(t, taskIndex, resp.TaskIndex)
 	})
 
+	t.Run("legacy digests are aggregated with WithLegacyDigest", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator1 := types.TestOperator{
+			OperatorId:     types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(100)},
+			BlsKeypair:     newBlsKeyPairPanics("0x1"),
+		}
+		blockNum := uint32(1)
+		requestData := []byte("legacy")
+		legacyDigest, err := common.Keccak256HashFn(requestData)
+		assert.NoError(t, err)
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, common.Keccak256HashFn, logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithLegacyDigest(),
+		)
+
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator1.OperatorId], types.TaskIndex(0), blockNum, requestData).Return(&pb.CertifyResponse{
+			Signature: testOperator1.BlsKeypair.SignMessage(legacyDigest).Marshal(),
+			Data:      requestData,
+		}, nil)
+		result, err := aggregatorService.GetCertificate(ctx, 0, blockNum, 0, 100, requestData, time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, legacyDigest, result.TaskResponseDigest)
+		assert.Equal(t, legacyDigest, result.Operators[0].ResponseDigest)
+	})
+
+	t.Run("slow task does not block concurrent tasks", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator1 := types.TestOperator{
+			OperatorId: types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(100),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x1"),
+		}
+		blockNum := uint32(1)
+		slowTaskIndex := types.TaskIndex(1)
+		fastTaskIndex := types.TaskIndex(2)
+		quorumNumber := types.QuorumNum(0)
+		quorumThresholdPercentage := types.QuorumThresholdPercentage(100)
+		slowRequestData := []byte("slow")
+		fastRequestData := []byte("fast")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{quorumNumber}, blockNum)
+
+		release := make(chan struct{})
+		for _, operator := range operators {
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operator, slowTaskIndex, blockNum, slowRequestData).DoAndReturn(
+				func(context.Context, types.OperatorAvsState, types.TaskIndex, types.BlockNum, []byte) (*pb.CertifyResponse, error) {
+					<-release
+					return signedResponse(testOperator1, slowTaskIndex, blockNum, slowRequestData), nil
+				},
+			)
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operator, fastTaskIndex, blockNum, fastRequestData).Return(
+				signedResponse(testOperator1, fastTaskIndex, blockNum, fastRequestData), nil,
+			)
+		}
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		type result struct {
+			resp *aggregator.TaskResult
+			err  error
+		}
+		slowResultC := make(chan result, 1)
+		go func() {
+			resp, err := aggregatorService.GetCertificate(ctx, slowTaskIndex, blockNum, quorumNumber, quorumThresholdPercentage, slowRequestData, 5*time.Second)
+			slowResultC <- result{resp, err}
+		}()
+
+		resp, err := aggregatorService.GetCertificate(ctx, fastTaskIndex, blockNum, quorumNumber, quorumThresholdPercentage, fastRequestData, 5*time.Second)
+		assert.NoError(t, err)
+		assert.Equal(t, fastTaskIndex, resp.TaskIndex)
+		assert.Equal(t, types.TaskResponse(fastRequestData), resp.TaskResponse)
+
+		select {
+		case <-slowResultC:
+			t.Fatal("slow task finished before its operator responded")
+		default:
+		}
+
+		close(release)
+		slowResult := <-slowResultC
+		assert.NoError(t, slowResult.err)
+		assert.Equal(t, slowTaskIndex, slowResult.resp.TaskIndex)
+		assert.Equal(t, types.TaskResponse(slowRequestData), slowResult.resp.TaskResponse)
+	})
+
+	t.Run("multi quorum certificate requires every quorum threshold", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator1 := types.TestOperator{
+			OperatorId: types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(100),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x1"),
+		}
+		testOperator2 := types.TestOperator{
+			OperatorId: types.OperatorId{2},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				1: big.NewInt(200),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x2"),
+		}
+		testOperators := map[types.OperatorId]types.TestOperator{
+			testOperator1.OperatorId: testOperator1,
+			testOperator2.OperatorId: testOperator2,
+		}
+		blockNum := uint32(1)
+		taskIndex := types.TaskIndex(3)
+		quorumNumbers := types.QuorumNums{0, 1}
+		quorumThresholdPercentages := types.QuorumThresholdPercentages{100, 100}
+		requestData := []byte("multi quorum")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1, testOperator2})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, quorumNumbers, blockNum)
+
+		for operatorId, operator := range operators {
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operator, taskIndex, blockNum, requestData).Return(
+				signedResponse(testOperators[operatorId], taskIndex, blockNum, requestData), nil,
+			)
+		}
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		resp, err := aggregatorService.GetMultiQuorumCertificate(
+			ctx,
+			taskIndex,
+			blockNum,
+			quorumNumbers,
+			quorumThresholdPercentages,
+			requestData,
+			5*time.Second,
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, taskIndex, resp.TaskIndex)
+		assert.Len(t, resp.QuorumApksG1, len(quorumNumbers))
+		assert.Empty(t, resp.NonSignersPubkeysG1)
+	})
+
+	t.Run("multi quorum certificate rejects unordered quorums", func(t *testing.T) {
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(1, nil)
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		_, err := aggregatorService.GetMultiQuorumCertificate(
+			context.Background(),
+			types.TaskIndex(4),
+			1,
+			types.QuorumNums{1, 0},
+			types.QuorumThresholdPercentages{50, 50},
+			[]byte("unordered"),
+			time.Second,
+		)
+		assert.Error(t, err)
+	})
+
+	t.Run("certificate is persisted to the certificate store", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator1 := types.TestOperator{
+			OperatorId: types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(100),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x1"),
+		}
+		testOperator2 := types.TestOperator{
+			OperatorId: types.OperatorId{2},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(10),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x2"),
+		}
+		blockNum := uint32(1)
+		taskIndex := types.TaskIndex(5)
+		quorumNumber := types.QuorumNum(0)
+		quorumThresholdPercentage := types.QuorumThresholdPercentage(50)
+		requestData := []byte("stored")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1, testOperator2})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{quorumNumber}, blockNum)
+
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator1.OperatorId], taskIndex, blockNum, requestData).Return(
+			signedResponse(testOperator1, taskIndex, blockNum, requestData), nil,
+		)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator2.OperatorId], taskIndex, blockNum, requestData).Return(
+			nil, errors.New("unreachable"),
+		)
+
+		certificateStore := store.NewInMemoryCertificateStore()
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithCertificateStore(certificateStore),
+		)
+
+		resp, err := aggregatorService.GetCertificate(ctx, taskIndex, blockNum, quorumNumber, quorumThresholdPercentage, requestData, 5*time.Second)
+		assert.NoError(t, err)
+
+		certificate, err := certificateStore.GetByTaskIndex(ctx, taskIndex)
+		assert.NoError(t, err)
+		assert.Equal(t, resp.TaskResponseDigest, certificate.Response.TaskResponseDigest)
+		assert.Equal(t, requestData, certificate.RequestData)
+		assert.Equal(t, blockNum, certificate.ReferenceBlockNumber)
+		assert.Equal(t, []types.OperatorId{testOperator1.OperatorId}, certificate.Signers)
+		assert.Equal(t, []types.OperatorId{testOperator2.OperatorId}, certificate.NonSigners)
+	})
+
+	t.Run("task policies decide when to stop collecting signatures", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator1 := types.TestOperator{
+			OperatorId: types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(100),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x1"),
+		}
+		testOperator2 := types.TestOperator{
+			OperatorId: types.OperatorId{2},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+				0: big.NewInt(10),
+			},
+			BlsKeypair: newBlsKeyPairPanics("0x2"),
+		}
+		blockNum := uint32(1)
+		requestData := []byte("policy")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1, testOperator2})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		// the second operator is not needed to reach the threshold and answers late
+		expectSlowSecondOperator := func(taskIndex types.TaskIndex) {
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator1.OperatorId], taskIndex, blockNum, requestData).Return(
+				signedResponse(testOperator1, taskIndex, blockNum, requestData), nil,
+			)
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator2.OperatorId], taskIndex, blockNum, requestData).DoAndReturn(
+				func(context.Context, types.OperatorAvsState, types.TaskIndex, types.BlockNum, []byte) (*pb.CertifyResponse, error) {
+					time.Sleep(300 * time.Millisecond)
+					return signedResponse(testOperator2, taskIndex, blockNum, requestData), nil
+				},
+			)
+		}
+
+		certify := func(policy aggregator.TaskPolicy) (*aggregator.TaskResult, time.Duration) {
+			task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+				ReferenceBlockNumber:       blockNum,
+				QuorumNumbers:              types.QuorumNums{0},
+				QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+				Data:                       requestData,
+				TimeToExpiry:               5 * time.Second,
+				Policy:                     &policy,
+			})
+			assert.NoError(t, err)
+			expectSlowSecondOperator(task.TaskIndex)
+
+			start := time.Now()
+			resp, err := aggregatorService.CertifyTask(ctx, task)
+			assert.NoError(t, err)
+			return resp, time.Since(start)
+		}
+
+		resp, elapsed := certify(aggregator.TaskPolicy{Mode: aggregator.ReturnAtThreshold})
+		assert.Less(t, elapsed, 300*time.Millisecond)
+		assert.Len(t, resp.NonSignersPubkeysG1, 1)
+
+		resp, _ = certify(aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive, Window: 50 * time.Millisecond})
+		assert.Empty(t, resp.NonSignersPubkeysG1)
+
+		// let the late response of the ReturnAtThreshold task drain before the mock controller finishes
+		time.Sleep(300 * time.Millisecond)
+	})
+
+	t.Run("outcome of every operator is reported", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := make([]types.TestOperator, 6)
+		for i := range testOperators {
+			stake := big.NewInt(10)
+			if i == 0 {
+				stake = big.NewInt(100)
+			}
+			testOperators[i] = types.TestOperator{
+				OperatorId: types.OperatorId{byte(i + 1)},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+					0: stake,
+				},
+				BlsKeypair: newBlsKeyPairPanics(fmt.Sprintf("0x%d", i+1)),
+			}
+		}
+		blockNum := uint32(1)
+		requestData := []byte("outcome")
+		divergentData := []byte("divergent")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithDefaultTaskPolicy(aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive, Window: 50 * time.Millisecond}),
+		)
+
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               5 * time.Second,
+		})
+		assert.NoError(t, err)
+
+		expect := func(operator types.TestOperator) *gomock.Call {
+			return fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[operator.OperatorId], task.TaskIndex, blockNum, requestData)
+		}
+		expect(testOperators[0]).Return(signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil)
+		expect(testOperators[1]).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+		expect(testOperators[2]).Return(nil, status.Error(codes.DeadlineExceeded, "deadline exceeded"))
+		expect(testOperators[3]).Return(nil, status.Error(codes.InvalidArgument, "bad request"))
+		expect(testOperators[4]).Return(&pb.CertifyResponse{Signature: []byte{1, 2, 3}, Data: requestData}, nil)
+		expect(testOperators[5]).Return(signedResponse(testOperators[5], task.TaskIndex, blockNum, divergentData), nil)
+
+		result, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+
+		expectedClasses := []aggregator.OutcomeClass{
+			aggregator.OutcomeSigned,
+			aggregator.OutcomeUnreachable,
+			aggregator.OutcomeTimeout,
+			aggregator.OutcomeRpcError,
+			aggregator.OutcomeMalformedSignature,
+			aggregator.OutcomeDivergentResponse,
+		}
+		assert.Len(t, result.Operators, len(expectedClasses))
+		for i, outcome := range result.Operators {
+			assert.Equal(t, testOperators[i].OperatorId, outcome.OperatorId)
+			assert.Equal(t, operators[outcome.OperatorId].OperatorInfo.Socket, outcome.Socket)
+			assert.Equal(t, expectedClasses[i], outcome.Class, "operator %d", i+1)
+		}
+		assert.Equal(t, result.TaskResponseDigest, result.Operators[0].ResponseDigest)
+		divergentDigest := types.TaskResponseDigest(testDomain.Digest(uint32(task.TaskIndex), blockNum, divergentData))
+		assert.Equal(t, divergentDigest, result.Operators[5].ResponseDigest)
+		assert.Error(t, result.Operators[1].Err)
+
+		// the outcomes are also returned when no certificate could be produced
+		task, err = aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               200 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+		for _, operator := range testOperators {
+			expect(operator).Return(nil, status.Error(codes.Unavailable, "connection refused"))
+		}
+
+		result, err = aggregatorService.CertifyTask(ctx, task)
+		assert.Error(t, err)
+		assert.Nil(t, result.BlsAggregationServiceResponse)
+		assert.Len(t, result.Operators, len(testOperators))
+		for _, outcome := range result.Operators {
+			assert.Equal(t, aggregator.OutcomeUnreachable, outcome.Class)
+		}
+	})
+
+	t.Run("diverging responses are summarized", func(t *testing.T) {
+		ctx := context.Background()
+
+		stakes := []int64{60, 30, 10}
+		testOperators := make([]types.TestOperator, len(stakes))
+		for i, stake := range stakes {
+			testOperators[i] = types.TestOperator{
+				OperatorId: types.OperatorId{byte(i + 1)},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+					0: big.NewInt(stake),
+				},
+				BlsKeypair: newBlsKeyPairPanics(fmt.Sprintf("0x%d", i+1)),
+			}
+		}
+		blockNum := uint32(1)
+		requestData := []byte("request")
+		leadingData := []byte("leading")
+		divergentData := []byte("divergent")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		var reported *aggregator.DivergenceSummary
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithDefaultTaskPolicy(aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive, Window: 50 * time.Millisecond}),
+			aggregator.WithDivergenceHandler(0.8, func(summary *aggregator.DivergenceSummary) {
+				reported = summary
+			}),
+		)
+
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               5 * time.Second,
+		})
+		assert.NoError(t, err)
+
+		for i, data := range [][]byte{leadingData, divergentData, leadingData} {
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[i].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+				signedResponse(testOperators[i], task.TaskIndex, blockNum, data), nil,
+			)
+		}
+
+		result, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+
+		leadingDigest := types.TaskResponseDigest(testDomain.Digest(uint32(task.TaskIndex), blockNum, leadingData))
+		divergentDigest := types.TaskResponseDigest(testDomain.Digest(uint32(task.TaskIndex), blockNum, divergentData))
+		summary := result.Divergence
+		assert.True(t, summary.Diverged())
+		assert.Len(t, summary.Responses, 2)
+		assert.Equal(t, leadingDigest, summary.Responses[0].ResponseDigest)
+		assert.Equal(t, []types.OperatorId{testOperators[0].OperatorId, testOperators[2].OperatorId}, summary.Responses[0].Operators)
+		assert.Equal(t, big.NewInt(70), summary.Responses[0].StakePerQuorum[0])
+		assert.InDelta(t, 0.7, summary.Responses[0].StakeShare, 1e-9)
+		assert.Equal(t, divergentDigest, summary.Responses[1].ResponseDigest)
+		assert.Equal(t, []types.OperatorId{testOperators[1].OperatorId}, summary.Responses[1].Operators)
+		assert.InDelta(t, 0.3, summary.Responses[1].StakeShare, 1e-9)
+		assert.Same(t, summary, reported)
+	})
+
+	t.Run("metrics record task results", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := []types.TestOperator{
+			{
+				OperatorId:     types.OperatorId{1},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(60)},
+				BlsKeypair:     newBlsKeyPairPanics("0x1"),
+			},
+			{
+				OperatorId:     types.OperatorId{2},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(40)},
+				BlsKeypair:     newBlsKeyPairPanics("0x2"),
+			},
+		}
+		blockNum := uint32(1)
+		requestData := []byte("metrics")
+		expiringRequestData := []byte("metrics expiring")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		reg := prometheus.NewRegistry()
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithMetrics(aggregator.NewMetrics(reg)),
+		)
+
+		createTask := func(data []byte, timeToExpiry time.Duration) *aggregator.Task {
+			task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+				ReferenceBlockNumber:       blockNum,
+				QuorumNumbers:              types.QuorumNums{0},
+				QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+				Data:                       data,
+				TimeToExpiry:               timeToExpiry,
+			})
+			assert.NoError(t, err)
+			return task
+		}
+
+		task := createTask(requestData, 5*time.Second)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil,
+		)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			nil, status.Error(codes.Unavailable, "unavailable"),
+		)
+		_, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+
+		expiringTask := createTask(expiringRequestData, 200*time.Millisecond)
+		for _, operator := range operators {
+			fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operator, expiringTask.TaskIndex, blockNum, expiringRequestData).Return(
+				nil, status.Error(codes.Unavailable, "unavailable"),
+			)
+		}
+		_, err = aggregatorService.CertifyTask(ctx, expiringTask)
+		assert.Error(t, err)
+
+		families, err := reg.Gather()
+		assert.NoError(t, err)
+		metrics := make(map[string]*dto.MetricFamily, len(families))
+		for _, family := range families {
+			metrics[family.GetName()] = family
+		}
+
+		assert.Equal(t, 2.0, metrics["teal_aggregator_tasks_started_total"].GetMetric()[0].GetCounter().GetValue())
+		assert.Equal(t, 0.0, metrics["teal_aggregator_tasks_in_flight"].GetMetric()[0].GetGauge().GetValue())
+		completed := make(map[string]float64)
+		for _, metric := range metrics["teal_aggregator_tasks_completed_total"].GetMetric() {
+			completed[metric.GetLabel()[0].GetValue()] = metric.GetCounter().GetValue()
+		}
+		assert.Equal(t, map[string]float64{aggregator.TaskSucceeded: 1, aggregator.TaskExpired: 1}, completed)
+
+		assert.Equal(t, uint64(1), metrics["teal_aggregator_time_to_threshold_seconds"].GetMetric()[0].GetHistogram().GetSampleCount())
+		signedStake := metrics["teal_aggregator_signed_stake_percentage"].GetMetric()[0].GetHistogram()
+		assert.Equal(t, uint64(1), signedStake.GetSampleCount())
+		assert.InDelta(t, 60, signedStake.GetSampleSum(), 1e-9)
+
+		operatorErrors := make(map[string]float64)
+		for _, metric := range metrics["teal_aggregator_operator_errors_total"].GetMetric() {
+			labels := make(map[string]string)
+			for _, label := range metric.GetLabel() {
+				labels[label.GetName()] = label.GetValue()
+			}
+			operatorErrors[labels["operator_id"]+"/"+labels["outcome"]] = metric.GetCounter().GetValue()
+		}
+		assert.Equal(t, map[string]float64{
+			fmt.Sprintf("%x/unreachable", testOperators[0].OperatorId): 1,
+			fmt.Sprintf("%x/unreachable", testOperators[1].OperatorId): 2,
+		}, operatorErrors)
+		assert.Len(t, metrics["teal_aggregator_operator_response_latency_seconds"].GetMetric(), 2)
+	})
+
+	t.Run("phases are traced", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperator := types.TestOperator{
+			OperatorId:     types.OperatorId{1},
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(100)},
+			BlsKeypair:     newBlsKeyPairPanics("0x1"),
+		}
+		blockNum := uint32(1)
+		requestData := []byte("traced")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator})
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		spans := tracetest.NewSpanRecorder()
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans))),
+		)
+
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{100},
+			Data:                       requestData,
+			TimeToExpiry:               5 * time.Second,
+		})
+		assert.NoError(t, err)
+
+		var requestSpan trace.SpanContext
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperator.OperatorId], task.TaskIndex, blockNum, requestData).DoAndReturn(
+			func(ctx context.Context, _ types.OperatorAvsState, _ types.TaskIndex, _ types.BlockNum, _ []byte) (*pb.CertifyResponse, error) {
+				requestSpan = trace.SpanContextFromContext(ctx)
+				return signedResponse(testOperator, task.TaskIndex, blockNum, requestData), nil
+			},
+		)
+		_, err = aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+
+		ended := make(map[string]sdktrace.ReadOnlySpan)
+		for _, span := range spans.Ended() {
+			ended[span.Name()] = span
+		}
+		root := ended["GetCertificate"]
+		if assert.NotNil(t, root) {
+			for _, name := range []string{"InitializeNewTask", "GetOperatorsAvsStateAtBlock", "GetQuorumsAvsStateAtBlock", "RequestCertification", "ProcessNewSignature", "WaitForAggregation"} {
+				if assert.Contains(t, ended, name) {
+					assert.Equal(t, root.SpanContext().TraceID(), ended[name].SpanContext().TraceID(), name)
+				}
+			}
+			assert.Equal(t, ended["RequestCertification"].SpanContext().SpanID(), requestSpan.SpanID())
+		}
+	})
+
+	t.Run("outstanding requests are canceled once the task completes", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := []types.TestOperator{
+			{
+				OperatorId:     types.OperatorId{1},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(60)},
+				BlsKeypair:     newBlsKeyPairPanics("0x1"),
+			},
+			{
+				OperatorId:     types.OperatorId{2},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(40)},
+				BlsKeypair:     newBlsKeyPairPanics("0x2"),
+			},
+		}
+		blockNum := uint32(1)
+		requestData := []byte("late")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		reg := prometheus.NewRegistry()
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithMetrics(aggregator.NewMetrics(reg)),
+		)
+		defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               time.Minute,
+		})
+		assert.NoError(t, err)
+
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil,
+		)
+		// the hung operator answers once its request is canceled, which is too late
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).DoAndReturn(
+			func(ctx context.Context, _ types.OperatorAvsState, _ types.TaskIndex, _ types.BlockNum, _ []byte) (*pb.CertifyResponse, error) {
+				<-ctx.Done()
+				return signedResponse(testOperators[1], task.TaskIndex, blockNum, requestData), nil
+			},
+		)
+
+		result, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Equal(t, aggregator.OutcomePending, result.Operators[1].Class)
+
+		waited := make(chan struct{})
+		go func() {
+			aggregatorService.Wait()
+			close(waited)
+		}()
+		select {
+		case <-waited:
+		case <-time.After(5 * time.Second):
+			t.Fatal("the requests of the task were not canceled")
+		}
+		assert.Equal(t, 1.0, counterValue(t, reg, "teal_aggregator_late_results_dropped_total"))
+	})
+
+	t.Run("hung operators time out before the task expires", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := []types.TestOperator{
+			{
+				OperatorId:     types.OperatorId{1},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(60)},
+				BlsKeypair:     newBlsKeyPairPanics("0x1"),
+			},
+			{
+				OperatorId:     types.OperatorId{2},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(40)},
+				BlsKeypair:     newBlsKeyPairPanics("0x2"),
+			},
+		}
+		blockNum := uint32(1)
+		requestData := []byte("hung")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithDefaultTaskPolicy(aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive, Window: 50 * time.Millisecond}),
+			aggregator.WithOperatorTimeout(time.Hour),
+		)
+
+		_, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               time.Second,
+			OperatorTimeout:            time.Second,
+		})
+		assert.Error(t, err)
+
+		// the task's operator timeout overrides the one of the service
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               10 * time.Second,
+			OperatorTimeout:            100 * time.Millisecond,
+		})
+		assert.NoError(t, err)
+
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil,
+		)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).DoAndReturn(
+			func(ctx context.Context, _ types.OperatorAvsState, _ types.TaskIndex, _ types.BlockNum, _ []byte) (*pb.CertifyResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		)
+
+		start := time.Now()
+		result, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, aggregator.OutcomeSigned, result.Operators[0].Class)
+		assert.Equal(t, aggregator.OutcomeTimeout, result.Operators[1].Class)
+		assert.GreaterOrEqual(t, result.Operators[1].Latency, 100*time.Millisecond)
+	})
+
+	t.Run("operators outside the first wave are contacted if the thresholds are not met", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := []types.TestOperator{
+			{
+				OperatorId:     types.OperatorId{1},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(60)},
+				BlsKeypair:     newBlsKeyPairPanics("0x1"),
+			},
+			{
+				OperatorId:     types.OperatorId{2},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(40)},
+				BlsKeypair:     newBlsKeyPairPanics("0x2"),
+			},
+		}
+		blockNum := uint32(1)
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithDefaultTaskPolicy(aggregator.TaskPolicy{Mode: aggregator.ReturnAtThreshold}),
+			aggregator.WithSelectionStrategy(aggregator.TopNByStake(1), 100*time.Millisecond),
+		)
+		createTask := func(data []byte) *aggregator.Task {
+			task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+				ReferenceBlockNumber:       blockNum,
+				QuorumNumbers:              types.QuorumNums{0},
+				QuorumThresholdPercentages: types.QuorumThresholdPercentages{40},
+				Data:                       data,
+				TimeToExpiry:               5 * time.Second,
+			})
+			assert.NoError(t, err)
+			return task
+		}
+
+		// the first wave meets the thresholds
+		requestData := []byte("first wave")
+		task := createTask(requestData)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil,
+		)
+		result, err := aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Equal(t, aggregator.OutcomeSigned, result.Operators[0].Class)
+		assert.Equal(t, aggregator.OutcomeNotContacted, result.Operators[1].Class)
+
+		// the first wave fails
+		requestData = []byte("failing first wave")
+		task = createTask(requestData)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			nil, status.Error(codes.Unavailable, "unavailable"),
+		)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[1], task.TaskIndex, blockNum, requestData), nil,
+		)
+		result, err = aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Equal(t, aggregator.OutcomeUnreachable, result.Operators[0].Class)
+		assert.Equal(t, aggregator.OutcomeSigned, result.Operators[1].Class)
+
+		// the first wave hangs past the second wave delay
+		requestData = []byte("hung first wave")
+		task = createTask(requestData)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).DoAndReturn(
+			func(ctx context.Context, _ types.OperatorAvsState, _ types.TaskIndex, _ types.BlockNum, _ []byte) (*pb.CertifyResponse, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[1], task.TaskIndex, blockNum, requestData), nil,
+		)
+		start := time.Now()
+		result, err = aggregatorService.CertifyTask(ctx, task)
+		assert.NoError(t, err)
+		assert.Less(t, time.Since(start), 4*time.Second)
+		assert.Equal(t, aggregator.OutcomeSigned, result.Operators[1].Class)
+		aggregatorService.Wait()
+	})
+
+	t.Run("invalid task policy is rejected", func(t *testing.T) {
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(1, nil)
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		_, err := aggregatorService.CreateTask(context.Background(), aggregator.TaskRequest{
+			ReferenceBlockNumber:       1,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       []byte("invalid policy"),
+			TimeToExpiry:               time.Second,
+			Policy:                     &aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive},
+		})
+		assert.Error(t, err)
+
+		// the window must leave time to collect signatures before the task expires
+		policy := &aggregator.TaskPolicy{Mode: aggregator.WaitForAllResponsive, Window: time.Second}
+		_, err = aggregatorService.CreateTask(context.Background(), aggregator.TaskRequest{
+			ReferenceBlockNumber:       1,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       []byte("window too long"),
+			TimeToExpiry:               time.Second,
+			Policy:                     policy,
+		})
+		assert.ErrorContains(t, err, "time to expiry")
+
+		// a default policy is checked against the expiry of every task
+		aggregatorService = aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+			aggregator.WithDefaultTaskPolicy(*policy),
+		)
+		_, err = aggregatorService.GetCertificate(context.Background(), 1, 1, 0, 50, []byte("window too long"), time.Second)
+		assert.ErrorContains(t, err, "time to expiry")
+	})
+
+	t.Run("task completes while a signature is being processed", func(t *testing.T) {
+		ctx := context.Background()
+
+		testOperators := []types.TestOperator{
+			{
+				OperatorId:     types.OperatorId{1},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(60)},
+				BlsKeypair:     newBlsKeyPairPanics("0x1"),
+			},
+			{
+				OperatorId:     types.OperatorId{2},
+				StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(40)},
+				BlsKeypair:     newBlsKeyPairPanics("0x2"),
+			},
+		}
+		blockNum := uint32(1)
+		requestData := []byte("stalled")
+
+		logger := testutils.GetTestLogger()
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, testOperators)
+		operators, _ := fakeAvsRegistryService.GetOperatorsAvsStateAtBlock(ctx, types.QuorumNums{0}, blockNum)
+
+		blsAggService := &stalledBlsAggService{
+			BlsAggregationService: blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger),
+			stalledOperatorId:     testOperators[1].OperatorId,
+			processedC:            make(chan struct{}),
+			stalledC:              make(chan struct{}),
+		}
+		aggregatorService := aggregator.NewAggregatorService(
+			logger,
+			fakeAvsRegistryService,
+			blsAggService,
+			fakeOperatorRequester,
+			testDomain,
+		)
+
+		task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              types.QuorumNums{0},
+			QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+			Data:                       requestData,
+			TimeToExpiry:               10 * time.Second,
+		})
+		assert.NoError(t, err)
+
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[0].OperatorId], task.TaskIndex, blockNum, requestData).Return(
+			signedResponse(testOperators[0], task.TaskIndex, blockNum, requestData), nil,
+		)
+		// the second signature is processed once the first one met the thresholds
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), operators[testOperators[1].OperatorId], task.TaskIndex, blockNum, requestData).DoAndReturn(
+			func(context.Context, types.OperatorAvsState, types.TaskIndex, types.BlockNum, []byte) (*pb.CertifyResponse, error) {
+				<-blsAggService.processedC
+				return signedResponse(testOperators[1], task.TaskIndex, blockNum, requestData), nil
+			},
+		)
+
+		certifyCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		result, err := aggregatorService.CertifyTask(certifyCtx, task)
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		// the default policy aggregates for a second after the thresholds were met
+		assert.Less(t, time.Since(start), 3*time.Second)
+	})
+}
+
+// stalledBlsAggService stands in for a blsagg whose task goroutine exited while the
+// signature of stalledOperatorId was being handed to it: processing the signature
+// blocks until its context is done. The response of a task is only delivered once
+// the signature is stalled.
+type stalledBlsAggService struct {
+	blsagg.BlsAggregationService
+	stalledOperatorId types.OperatorId
+	// processedC is closed once another operator's signature was processed
+	processedC chan struct{}
+	// stalledC is closed once the signature of stalledOperatorId is being processed
+	stalledC chan struct{}
+}
+
+func (s *stalledBlsAggService) ProcessNewSignature(
+	ctx context.Context,
+	taskIndex types.TaskIndex,
+	taskResponse types.TaskResponse,
+	blsSignature *bls.Signature,
+	operatorId types.OperatorId,
+) error {
+	if operatorId == s.stalledOperatorId {
+		close(s.stalledC)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	err := s.BlsAggregationService.ProcessNewSignature(ctx, taskIndex, taskResponse, blsSignature, operatorId)
+	close(s.processedC)
+	return err
+}
+
+func (s *stalledBlsAggService) GetResponseChannel() <-chan blsagg.BlsAggregationServiceResponse {
+	responseC := make(chan blsagg.BlsAggregationServiceResponse)
+	go func() {
+		for resp := range s.BlsAggregationService.GetResponseChannel() {
+			<-s.stalledC
+			responseC <- resp
+		}
+	}()
+	return responseC
+}
+
+// counterValue returns the value of the unlabeled counter name of reg
+func counterValue(t *testing.T, reg prometheus.Gatherer, name string) float64 {
+	families, err := reg.Gather()
+	assert.NoError(t, err)
+	for _, family := range families {
+		if family.GetName() == name {
+			return family.GetMetric()[0].GetCounter().GetValue()
+		}
+	}
+	return 0
+}
+
+// testDomain is the signing domain of the tests' operators
+var testDomain = common.SigningDomain{
+	ChainId:         big.NewInt(31337),
+	VerifierAddress: gethcommon.HexToAddress("0x4242424242424242424242424242424242424242"),
+}
+
+// signedResponse answers the request for task taskIndex at blockNum with data signed by operator
+func signedResponse(operator types.TestOperator, taskIndex types.TaskIndex, blockNum uint32, data []byte) *pb.CertifyResponse {
+	digest := testDomain.Digest(uint32(taskIndex), blockNum, data)
+	return &pb.CertifyResponse{
+		Signature: operator.BlsKeypair.SignMessage(digest).Marshal(),
+		Data:      data,
+	}
 }
 
 func newBlsKeyPairPanics(hexKey string) *bls.KeyPair {
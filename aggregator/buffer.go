@@ -0,0 +1,51 @@
+package aggregator
+
+import "sync"
+
+// signatureBuffer passes signatures on to process, optionally holding them back until flush is called
+type signatureBuffer struct {
+	process func(operatorSignature)
+
+	// flushMu makes flush return only once the held signatures were processed, even
+	// if another flush took them
+	flushMu sync.Mutex
+	mu      sync.Mutex
+	held    []operatorSignature
+	flushed bool
+}
+
+// newSignatureBuffer returns a buffer that holds signatures back until flush if hold is set
+func newSignatureBuffer(hold bool, process func(operatorSignature)) *signatureBuffer {
+	return &signatureBuffer{
+		process: process,
+		flushed: !hold,
+	}
+}
+
+func (b *signatureBuffer) add(signature operatorSignature) {
+	b.mu.Lock()
+	if !b.flushed {
+		b.held = append(b.held, signature)
+		b.mu.Unlock()
+		return
+	}
+	b.mu.Unlock()
+
+	b.process(signature)
+}
+
+// flush processes all held signatures in order. Signatures added afterwards are processed immediately.
+func (b *signatureBuffer) flush() {
+	b.flushMu.Lock()
+	defer b.flushMu.Unlock()
+
+	b.mu.Lock()
+	held := b.held
+	b.held = nil
+	b.flushed = true
+	b.mu.Unlock()
+
+	for _, signature := range held {
+		b.process(signature)
+	}
+}
@@ -0,0 +1,299 @@
+package aggregator
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+)
+
+// ResponseGroup is a distinct response signed by one or more operators of a task
+type ResponseGroup struct {
+	ResponseDigest types.TaskResponseDigest
+	// Operators that signed the response, ordered by operator id
+	Operators []types.OperatorId
+	// StakePerQuorum is the stake of Operators in each of the task's quorums
+	StakePerQuorum map[types.QuorumNum]types.StakeAmount
+	// StakeShare is the lowest share, across the task's quorums, of the stake of all
+	// responding operators that signed this response
+	StakeShare float64
+}
+
+// DivergenceSummary groups the verified responses of a task by digest
+type DivergenceSummary struct {
+	TaskIndex types.TaskIndex
+	// Responses are ordered by descending stake share, the first one is the leading response
+	Responses []ResponseGroup
+}
+
+// Diverged reports whether operators signed more than one distinct response
+func (d *DivergenceSummary) Diverged() bool {
+	return len(d.Responses) > 1
+}
+
+// LeadingStakeShare returns the stake share of the leading response, or 0 if no response was verified
+func (d *DivergenceSummary) LeadingStakeShare() float64 {
+	if len(d.Responses) == 0 {
+		return 0
+	}
+	return d.Responses[0].StakeShare
+}
+
+// DivergenceHandler is called with the divergence summary of a task whose leading
+// response has less than the configured stake share. It is called synchronously
+// before the task's result is returned.
+type DivergenceHandler func(summary *DivergenceSummary)
+
+// summarizeDivergence groups the outcomes of operators whose signature was verified by response digest
+func summarizeDivergence(
+	taskIndex types.TaskIndex,
+	quorumNumbers types.QuorumNums,
+	outcomes []OperatorOutcome,
+	operators map[types.OperatorId]types.OperatorAvsState,
+) *DivergenceSummary {
+	groups := make(map[types.TaskResponseDigest]*ResponseGroup)
+	respondingStake := make(map[types.QuorumNum]*big.Int, len(quorumNumbers))
+	for _, quorumNumber := range quorumNumbers {
+		respondingStake[quorumNumber] = new(big.Int)
+	}
+
+	for _, outcome := range outcomes {
+		if outcome.Class != OutcomeSigned && outcome.Class != OutcomeDivergentResponse {
+			continue
+		}
+		group, ok := groups[outcome.ResponseDigest]
+		if !ok {
+			group = &ResponseGroup{
+				ResponseDigest: outcome.ResponseDigest,
+				StakePerQuorum: make(map[types.QuorumNum]types.StakeAmount, len(quorumNumbers)),
+			}
+			for _, quorumNumber := range quorumNumbers {
+				group.StakePerQuorum[quorumNumber] = new(big.Int)
+			}
+			groups[outcome.ResponseDigest] = group
+		}
+		group.Operators = append(group.Operators, outcome.OperatorId)
+
+		for _, quorumNumber := range quorumNumbers {
+			stake := operators[outcome.OperatorId].StakePerQuorum[quorumNumber]
+			if stake == nil {
+				continue
+			}
+			group.StakePerQuorum[quorumNumber].Add(group.StakePerQuorum[quorumNumber], stake)
+			respondingStake[quorumNumber].Add(respondingStake[quorumNumber], stake)
+		}
+	}
+
+	summary := &DivergenceSummary{
+		TaskIndex: taskIndex,
+		Responses: make([]ResponseGroup, 0, len(groups)),
+	}
+	for _, group := range groups {
+		group.StakeShare = 1
+		for _, quorumNumber := range quorumNumbers {
+			if respondingStake[quorumNumber].Sign() == 0 {
+				continue
+			}
+			share, _ := new(big.Rat).SetFrac(group.StakePerQuorum[quorumNumber], respondingStake[quorumNumber]).Float64()
+			if share < group.StakeShare {
+				group.StakeShare = share
+			}
+		}
+		summary.Responses = append(summary.Responses, *group)
+	}
+	sort.Slice(summary.Responses, func(i, j int) bool {
+		if summary.Responses[i].StakeShare != summary.Responses[j].StakeShare {
+			return summary.Responses[i].StakeShare > summary.Responses[j].StakeShare
+		}
+		return bytes.Compare(summary.Responses[i].ResponseDigest[:], summary.Responses[j].ResponseDigest[:]) < 0
+	})
+	return summary
+}
+
+// responseTally tracks the stake that signed each response digest of a task. Once its
+// window closes, blsagg certifies the digest of the last signature it aggregated, so after
+// a digest met the thresholds, signatures over other digests are kept away from it and
+// only verified locally.
+type responseTally struct {
+	// processMu serializes the signatures handed to blsagg, so that none over another
+	// digest can follow the signature that met the thresholds. mu isn't held meanwhile,
+	// so that a slow blsagg doesn't block completing or reading the tally.
+	processMu sync.Mutex
+	mu        sync.Mutex
+
+	operators                  map[types.OperatorId]types.OperatorAvsState
+	quorumNumbers              types.QuorumNums
+	quorumThresholdPercentages types.QuorumThresholdPercentages
+	totalStakePerQuorum        map[types.QuorumNum]*big.Int
+
+	signedStake map[types.TaskResponseDigest]map[types.QuorumNum]*big.Int
+	// leadingDigest is set once a digest met the thresholds of every quorum
+	leadingDigest *types.TaskResponseDigest
+	// thresholds is set when leadingDigest is and updated as its signed stake grows
+	thresholds atomic.Pointer[thresholdsSnapshot]
+	// thresholdsMetC is closed when leadingDigest is set
+	thresholdsMetC chan struct{}
+	// completed is set once the task's result was returned
+	completed bool
+}
+
+// thresholdsSnapshot describes the digest that met the thresholds of a task
+type thresholdsSnapshot struct {
+	digest types.TaskResponseDigest
+	// metAt is the time the digest met the thresholds
+	metAt time.Time
+	// signedStakePercentages is the percentage of each quorum's total stake that signed the digest
+	signedStakePercentages map[types.QuorumNum]float64
+}
+
+// errTaskCompleted is returned by submit for signatures received after the task completed
+var errTaskCompleted = errors.New("task completed")
+
+func newResponseTally(
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) *responseTally {
+	totalStakePerQuorum := make(map[types.QuorumNum]*big.Int, len(quorums))
+	for quorumNumber, quorum := range quorums {
+		totalStakePerQuorum[quorumNumber] = quorum.TotalStake
+	}
+	return &responseTally{
+		operators:                  operators,
+		quorumNumbers:              task.QuorumNumbers,
+		quorumThresholdPercentages: task.QuorumThresholdPercentages,
+		totalStakePerQuorum:        totalStakePerQuorum,
+		signedStake:                make(map[types.TaskResponseDigest]map[types.QuorumNum]*big.Int),
+		thresholdsMetC:             make(chan struct{}),
+	}
+}
+
+// submit hands signature to process unless another digest already met the thresholds,
+// in which case the signature is only verified against the operator's public key. The
+// result is passed to record before it is tallied, unless the task completed, in which
+// case errTaskCompleted is returned.
+func (t *responseTally) submit(
+	signature operatorSignature,
+	process func(operatorSignature) error,
+	record func(error),
+) error {
+	t.processMu.Lock()
+	defer t.processMu.Unlock()
+
+	// leadingDigest only changes while processMu is held
+	t.mu.Lock()
+	completed, leadingDigest := t.completed, t.leadingDigest
+	t.mu.Unlock()
+	if completed {
+		return errTaskCompleted
+	}
+
+	if leadingDigest != nil && *leadingDigest != signature.digest {
+		operator := t.operators[signature.operatorId]
+		ok, err := signature.signature.Verify(operator.OperatorInfo.Pubkeys.G2Pubkey, signature.digest)
+		if err == nil && !ok {
+			err = blsagg.IncorrectSignatureError
+		}
+		record(err)
+		return nil
+	}
+
+	err := process(signature)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// processing is canceled once the task completed
+	if err != nil && t.completed {
+		return errTaskCompleted
+	}
+	record(err)
+	if err != nil {
+		return nil
+	}
+
+	signedStake, ok := t.signedStake[signature.digest]
+	if !ok {
+		signedStake = make(map[types.QuorumNum]*big.Int, len(t.quorumNumbers))
+		for _, quorumNumber := range t.quorumNumbers {
+			signedStake[quorumNumber] = new(big.Int)
+		}
+		t.signedStake[signature.digest] = signedStake
+	}
+	for _, quorumNumber := range t.quorumNumbers {
+		if stake := t.operators[signature.operatorId].StakePerQuorum[quorumNumber]; stake != nil {
+			signedStake[quorumNumber].Add(signedStake[quorumNumber], stake)
+		}
+	}
+
+	switch {
+	case t.leadingDigest == nil && t.thresholdsMet(signedStake):
+		digest := signature.digest
+		t.leadingDigest = &digest
+		t.thresholds.Store(&thresholdsSnapshot{
+			digest:                 digest,
+			metAt:                  time.Now(),
+			signedStakePercentages: t.signedStakePercentages(signedStake),
+		})
+		close(t.thresholdsMetC)
+	case t.leadingDigest != nil && *t.leadingDigest == signature.digest:
+		thresholds := *t.thresholds.Load()
+		thresholds.signedStakePercentages = t.signedStakePercentages(signedStake)
+		t.thresholds.Store(&thresholds)
+	}
+	return nil
+}
+
+// complete makes submit drop the signatures received from now on
+func (t *responseTally) complete() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.completed = true
+}
+
+// thresholdsMetSignal returns a channel that is closed once a digest met the thresholds
+func (t *responseTally) thresholdsMetSignal() <-chan struct{} {
+	return t.thresholdsMetC
+}
+
+// thresholdsMetSnapshot describes the digest that met the thresholds, or is nil if none
+// did. It doesn't wait for the signatures being processed.
+func (t *responseTally) thresholdsMetSnapshot() *thresholdsSnapshot {
+	return t.thresholds.Load()
+}
+
+// signedStakePercentages returns the percentage of each quorum's total stake in signedStake
+func (t *responseTally) signedStakePercentages(signedStake map[types.QuorumNum]*big.Int) map[types.QuorumNum]float64 {
+	percentages := make(map[types.QuorumNum]float64, len(t.quorumNumbers))
+	for _, quorumNumber := range t.quorumNumbers {
+		totalStake := t.totalStakePerQuorum[quorumNumber]
+		if totalStake == nil || totalStake.Sign() == 0 || signedStake[quorumNumber] == nil {
+			percentages[quorumNumber] = 0
+			continue
+		}
+		share, _ := new(big.Rat).SetFrac(signedStake[quorumNumber], totalStake).Float64()
+		percentages[quorumNumber] = share * 100
+	}
+	return percentages
+}
+
+// thresholdsMet checks signedStake >= totalStake * threshold / 100 for every quorum, like blsagg does
+func (t *responseTally) thresholdsMet(signedStake map[types.QuorumNum]*big.Int) bool {
+	for i, quorumNumber := range t.quorumNumbers {
+		totalStake, ok := t.totalStakePerQuorum[quorumNumber]
+		if !ok {
+			return false
+		}
+		signed := new(big.Int).Mul(signedStake[quorumNumber], big.NewInt(100))
+		required := new(big.Int).Mul(totalStake, big.NewInt(int64(t.quorumThresholdPercentages[i])))
+		if signed.Cmp(required) < 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,174 @@
+package aggregator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// MetricsNamespace prefixes the names of the aggregator's metrics
+const MetricsNamespace = "teal_aggregator"
+
+// Task results, as labeled by the tasks_completed_total metric
+const (
+	TaskSucceeded = "succeeded"
+	TaskExpired   = "expired"
+	TaskFailed    = "failed"
+	TaskCanceled  = "canceled"
+)
+
+// Metrics are the Prometheus metrics of an AggregatorService
+type Metrics struct {
+	tasksStarted    prometheus.Counter
+	tasksCompleted  *prometheus.CounterVec
+	tasksInFlight   prometheus.Gauge
+	timeToThreshold prometheus.Histogram
+	signedStake     *prometheus.HistogramVec
+	operatorLatency *prometheus.HistogramVec
+	operatorErrors  *prometheus.CounterVec
+	lateResults     prometheus.Counter
+}
+
+// NewMetrics creates the metrics of an AggregatorService and registers them with reg
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	return &Metrics{
+		tasksStarted: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "tasks_started_total",
+				Help:      "Number of tasks the aggregator started certifying",
+			},
+		),
+		tasksCompleted: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "tasks_completed_total",
+				Help:      "Number of tasks that completed, by result: succeeded, expired, failed or canceled",
+			},
+			[]string{"result"},
+		),
+		tasksInFlight: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: MetricsNamespace,
+				Name:      "tasks_in_flight",
+				Help:      "Number of tasks being certified",
+			},
+		),
+		timeToThreshold: promauto.With(reg).NewHistogram(
+			prometheus.HistogramOpts{
+				Namespace: MetricsNamespace,
+				Name:      "time_to_threshold_seconds",
+				Help:      "Time from the start of a task until the signed stake met its thresholds, in seconds",
+				Buckets:   prometheus.ExponentialBuckets(0.01, 2, 12),
+			},
+		),
+		signedStake: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: MetricsNamespace,
+				Name:      "signed_stake_percentage",
+				Help:      "Percentage of a quorum's total stake that signed the certified response of a task",
+				Buckets:   prometheus.LinearBuckets(0, 10, 11),
+			},
+			[]string{"quorum"},
+		),
+		operatorLatency: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: MetricsNamespace,
+				Name:      "operator_response_latency_seconds",
+				Help:      "Time operators took to answer certification requests, including retries, in seconds",
+				Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+			},
+			[]string{"operator_id"},
+		),
+		operatorErrors: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "operator_errors_total",
+				Help:      "Number of tasks an operator did not sign, by outcome",
+			},
+			[]string{"operator_id", "outcome"},
+		),
+		lateResults: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "late_results_dropped_total",
+				Help:      "Number of operator signatures dropped because they arrived after their task completed",
+			},
+		),
+	}
+}
+
+// taskStarted counts a task as in flight until the returned function is called
+func (m *Metrics) taskStarted() func() {
+	if m == nil {
+		return func() {}
+	}
+	m.tasksStarted.Inc()
+	m.tasksInFlight.Inc()
+	return m.tasksInFlight.Dec
+}
+
+// observeTask records the result of task and how its operators took part in it
+func (m *Metrics) observeTask(task *Task, result *TaskResult, err error) {
+	if m == nil {
+		return
+	}
+	m.tasksCompleted.WithLabelValues(taskResultLabel(task, err)).Inc()
+	if result == nil {
+		return
+	}
+	for _, outcome := range result.Operators {
+		operatorId := fmt.Sprintf("%x", outcome.OperatorId)
+		if outcome.Class == OutcomeNotContacted {
+			continue
+		}
+		if outcome.Class != OutcomePending {
+			m.operatorLatency.WithLabelValues(operatorId).Observe(outcome.Latency.Seconds())
+		}
+		if outcome.Class != OutcomeSigned {
+			m.operatorErrors.WithLabelValues(operatorId, outcome.Class.String()).Inc()
+		}
+	}
+}
+
+// observeThreshold records the time a task took to meet its thresholds and the share
+// of each quorum's stake that signed its certified response
+func (m *Metrics) observeThreshold(timeToThreshold time.Duration, signedStakePercentages map[types.QuorumNum]float64) {
+	if m == nil {
+		return
+	}
+	m.timeToThreshold.Observe(timeToThreshold.Seconds())
+	for quorumNumber, percentage := range signedStakePercentages {
+		m.signedStake.WithLabelValues(fmt.Sprint(quorumNumber)).Observe(percentage)
+	}
+}
+
+func (m *Metrics) lateResultDropped() {
+	if m == nil {
+		return
+	}
+	m.lateResults.Inc()
+}
+
+// taskResultLabel classifies the error a task completed with
+func taskResultLabel(task *Task, err error) string {
+	switch {
+	case err == nil:
+		return TaskSucceeded
+	case errors.Is(err, context.Canceled):
+		return TaskCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return TaskExpired
+	// blsagg creates a new error every time a task expires, so it can only be recognized by its message
+	case errors.Unwrap(err) != nil && errors.Unwrap(err).Error() == blsagg.TaskExpiredErrorFn(task.TaskIndex).Error():
+		return TaskExpired
+	default:
+		return TaskFailed
+	}
+}
@@ -0,0 +1,184 @@
+package operatorrequester
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"google.golang.org/grpc"
+)
+
+// DefaultIdleTimeout is how long a connection is kept open without requests
+const DefaultIdleTimeout = 5 * time.Minute
+
+var ErrConnectionManagerClosed = errors.New("connection manager is closed")
+
+// connection is a client connection to an operator socket shared by all requests to it
+type connection struct {
+	conn *grpc.ClientConn
+	// operators that last advertised this socket
+	operators map[types.OperatorId]struct{}
+	inFlight  int
+	lastUsed  time.Time
+}
+
+// ConnectionManager keeps one client connection per operator socket open across requests.
+// Connections are closed once no operator advertises their socket anymore or once they
+// have been idle for the idle timeout. It is safe for concurrent use.
+type ConnectionManager struct {
+	dialOptions []grpc.DialOption
+	idleTimeout time.Duration
+
+	mu              sync.Mutex
+	connections     map[types.Socket]*connection
+	operatorSockets map[types.OperatorId]types.Socket
+	closed          bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewConnectionManager creates connections with dialOptions and starts evicting the ones
+// idle for longer than idleTimeout, DefaultIdleTimeout if it is 0. Close must be called
+// to release the connections.
+func NewConnectionManager(idleTimeout time.Duration, dialOptions ...grpc.DialOption) *ConnectionManager {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	m := &ConnectionManager{
+		dialOptions:     dialOptions,
+		idleTimeout:     idleTimeout,
+		connections:     make(map[types.Socket]*connection),
+		operatorSockets: make(map[types.OperatorId]types.Socket),
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+	}
+	go m.evictIdleConnections()
+	return m
+}
+
+// Acquire returns a connection to the socket operator is registered with. release must be
+// called once the request completed so that the connection can be evicted when idle.
+func (m *ConnectionManager) Acquire(operator types.OperatorAvsState) (conn *grpc.ClientConn, release func(), err error) {
+	socket := operator.OperatorInfo.Socket
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, nil, ErrConnectionManagerClosed
+	}
+
+	// the operator updated its registration, the previous socket may not be needed anymore
+	if previousSocket, ok := m.operatorSockets[operator.OperatorId]; ok && previousSocket != socket {
+		if previous, ok := m.connections[previousSocket]; ok {
+			delete(previous.operators, operator.OperatorId)
+			m.closeIfUnused(previousSocket, previous)
+		}
+	}
+	m.operatorSockets[operator.OperatorId] = socket
+
+	c, ok := m.connections[socket]
+	if !ok {
+		clientConn, err := grpc.NewClient(socket.String(), m.dialOptions...)
+		if err != nil {
+			return nil, nil, err
+		}
+		c = &connection{
+			conn:      clientConn,
+			operators: make(map[types.OperatorId]struct{}),
+		}
+		m.connections[socket] = c
+	}
+	c.operators[operator.OperatorId] = struct{}{}
+	c.inFlight++
+	c.lastUsed = time.Now()
+
+	var once sync.Once
+	release = func() {
+		once.Do(func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+
+			c.inFlight--
+			c.lastUsed = time.Now()
+			// the connection is gone if the manager was closed while the request was in flight
+			if m.connections[socket] == c {
+				m.closeIfUnused(socket, c)
+			}
+		})
+	}
+	return c.conn, release, nil
+}
+
+// Len returns the number of open connections
+func (m *ConnectionManager) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.connections)
+}
+
+// Close closes all connections. Requests still in flight fail.
+func (m *ConnectionManager) Close() error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return nil
+	}
+	m.closed = true
+	var errs []error
+	for socket, c := range m.connections {
+		errs = append(errs, c.conn.Close())
+		delete(m.connections, socket)
+	}
+	m.mu.Unlock()
+
+	close(m.stop)
+	<-m.done
+	return errors.Join(errs...)
+}
+
+// closeIfUnused closes the connection to socket if no operator advertises it and no request uses it.
+// m.mu must be held.
+func (m *ConnectionManager) closeIfUnused(socket types.Socket, c *connection) {
+	if len(c.operators) > 0 || c.inFlight > 0 {
+		return
+	}
+	c.conn.Close()
+	delete(m.connections, socket)
+}
+
+func (m *ConnectionManager) evictIdleConnections() {
+	defer close(m.done)
+
+	ticker := time.NewTicker(m.idleTimeout / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.evictIdle(time.Now())
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *ConnectionManager) evictIdle(now time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for socket, c := range m.connections {
+		if c.inFlight > 0 || now.Sub(c.lastUsed) < m.idleTimeout {
+			continue
+		}
+		c.conn.Close()
+		delete(m.connections, socket)
+		for operatorId := range c.operators {
+			if m.operatorSockets[operatorId] == socket {
+				delete(m.operatorSockets, operatorId)
+			}
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package operatorrequester
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func newTestOperator(id byte, socket string) types.OperatorAvsState {
+	return types.OperatorAvsState{
+		OperatorId:   types.OperatorId{id},
+		OperatorInfo: types.OperatorInfo{Socket: types.Socket(socket)},
+	}
+}
+
+func TestConnectionManager(t *testing.T) {
+	newManager := func(t *testing.T) *ConnectionManager {
+		m := NewConnectionManager(time.Minute, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		t.Cleanup(func() { m.Close() })
+		return m
+	}
+
+	t.Run("reuses connections to the same socket", func(t *testing.T) {
+		m := newManager(t)
+
+		conn1, release1, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release1()
+		conn2, release2, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release2()
+		// operators sharing a socket share the connection
+		conn3, release3, err := m.Acquire(newTestOperator(2, "localhost:32001"))
+		require.NoError(t, err)
+		release3()
+
+		assert.Same(t, conn1, conn2)
+		assert.Same(t, conn1, conn3)
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("closes the previous connection when an operator changes its socket", func(t *testing.T) {
+		m := newManager(t)
+
+		oldConn, release, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release()
+
+		newConn, release, err := m.Acquire(newTestOperator(1, "localhost:32002"))
+		require.NoError(t, err)
+		release()
+
+		assert.NotSame(t, oldConn, newConn)
+		assert.Equal(t, connectivity.Shutdown, oldConn.GetState())
+		assert.Equal(t, 1, m.Len())
+	})
+
+	t.Run("keeps a connection used by a request in flight", func(t *testing.T) {
+		m := newManager(t)
+
+		oldConn, release, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+
+		_, releaseNew, err := m.Acquire(newTestOperator(1, "localhost:32002"))
+		require.NoError(t, err)
+		releaseNew()
+		assert.NotEqual(t, connectivity.Shutdown, oldConn.GetState())
+
+		m.evictIdle(time.Now().Add(time.Hour))
+		assert.NotEqual(t, connectivity.Shutdown, oldConn.GetState())
+
+		release()
+		assert.Equal(t, connectivity.Shutdown, oldConn.GetState())
+	})
+
+	t.Run("evicts idle connections", func(t *testing.T) {
+		m := newManager(t)
+
+		conn, release, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release()
+
+		m.evictIdle(time.Now())
+		assert.Equal(t, 1, m.Len())
+
+		m.evictIdle(time.Now().Add(time.Minute))
+		assert.Equal(t, 0, m.Len())
+		assert.Equal(t, connectivity.Shutdown, conn.GetState())
+
+		newConn, release, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release()
+		assert.NotSame(t, conn, newConn)
+	})
+
+	t.Run("is safe for concurrent use", func(t *testing.T) {
+		m := newManager(t)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				sockets := []string{"localhost:32001", "localhost:32002"}
+				_, release, err := m.Acquire(newTestOperator(byte(i%5), sockets[i%2]))
+				assert.NoError(t, err)
+				m.evictIdle(time.Now())
+				release()
+			}(i)
+		}
+		wg.Wait()
+		assert.LessOrEqual(t, m.Len(), 2)
+	})
+
+	t.Run("fails after close", func(t *testing.T) {
+		m := newManager(t)
+
+		conn, release, err := m.Acquire(newTestOperator(1, "localhost:32001"))
+		require.NoError(t, err)
+		release()
+
+		require.NoError(t, m.Close())
+		assert.Equal(t, connectivity.Shutdown, conn.GetState())
+
+		_, _, err = m.Acquire(newTestOperator(1, "localhost:32001"))
+		assert.ErrorIs(t, err, ErrConnectionManagerClosed)
+	})
+}
@@ -0,0 +1,174 @@
+package operatorrequester_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/testutils"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	operatorrequester "github.com/Layr-Labs/teal/aggregator/operator_requester"
+	"github.com/Layr-Labs/teal/aggregator/operator_requester/mocks"
+	pb "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestRetryingRequester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := testutils.GetTestLogger()
+	operator := types.OperatorAvsState{OperatorId: types.OperatorId{1}}
+	data := []byte("retry")
+
+	policy := operatorrequester.RetryPolicy{
+		MaxAttempts:       3,
+		InitialBackoff:    10 * time.Millisecond,
+		MaxBackoff:        20 * time.Millisecond,
+		BackoffMultiplier: 2,
+		RetryableCodes:    []codes.Code{codes.Unavailable},
+	}
+
+	t.Run("retries retryable errors until success", func(t *testing.T) {
+		next := mocks.NewMockOperatorRequester(ctrl)
+		requester, err := operatorrequester.NewRetryingRequester(logger, next, policy)
+		require.NoError(t, err)
+
+		gomock.InOrder(
+			next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(0), types.BlockNum(100), data).Return(nil, status.Error(codes.Unavailable, "connection reset")),
+			next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(0), types.BlockNum(100), data).Return(nil, status.Error(codes.Unavailable, "connection reset")),
+			next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(0), types.BlockNum(100), data).Return(&pb.CertifyResponse{Data: data}, nil),
+		)
+
+		resp, err := requester.RequestCertification(context.Background(), operator, 0, 100, data)
+		assert.NoError(t, err)
+		assert.Equal(t, data, resp.Data)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		next := mocks.NewMockOperatorRequester(ctrl)
+		requester, err := operatorrequester.NewRetryingRequester(logger, next, policy)
+		require.NoError(t, err)
+
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(1), types.BlockNum(100), data).Return(nil, status.Error(codes.Unavailable, "down")).Times(3)
+
+		_, err = requester.RequestCertification(context.Background(), operator, 1, 100, data)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		next := mocks.NewMockOperatorRequester(ctrl)
+		requester, err := operatorrequester.NewRetryingRequester(logger, next, policy)
+		require.NoError(t, err)
+
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(2), types.BlockNum(100), data).Return(nil, status.Error(codes.InvalidArgument, "bad request"))
+
+		_, err = requester.RequestCertification(context.Background(), operator, 2, 100, data)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("does not retry past the deadline", func(t *testing.T) {
+		next := mocks.NewMockOperatorRequester(ctrl)
+		requester, err := operatorrequester.NewRetryingRequester(logger, next, policy)
+		require.NoError(t, err)
+
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(3), types.BlockNum(100), data).Return(nil, status.Error(codes.Unavailable, "down"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+		defer cancel()
+		_, err = requester.RequestCertification(ctx, operator, 3, 100, data)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("waits for the retry delay of the operator", func(t *testing.T) {
+		next := mocks.NewMockOperatorRequester(ctrl)
+		requester, err := operatorrequester.NewRetryingRequester(logger, next, policy)
+		require.NoError(t, err)
+
+		rateLimited := func(delay time.Duration) error {
+			st, err := status.New(codes.Unavailable, "rate limited").WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)})
+			require.NoError(t, err)
+			return st.Err()
+		}
+
+		gomock.InOrder(
+			next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(4), types.BlockNum(100), data).Return(nil, rateLimited(50*time.Millisecond)),
+			next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(4), types.BlockNum(100), data).Return(&pb.CertifyResponse{Data: data}, nil),
+		)
+		start := time.Now()
+		_, err = requester.RequestCertification(context.Background(), operator, 4, 100, data)
+		assert.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+
+		// a delay past the deadline isn't waited for
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(5), types.BlockNum(100), data).Return(nil, rateLimited(time.Minute))
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_, err = requester.RequestCertification(ctx, operator, 5, 100, data)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("rejects invalid policies", func(t *testing.T) {
+		_, err := operatorrequester.NewRetryingRequester(logger, mocks.NewMockOperatorRequester(ctrl), operatorrequester.RetryPolicy{})
+		assert.Error(t, err)
+	})
+}
+
+func TestHedgingRequester(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	logger := testutils.GetTestLogger()
+	operator := types.OperatorAvsState{OperatorId: types.OperatorId{1}}
+	data := []byte("hedge")
+
+	policy := operatorrequester.HedgingPolicy{
+		Percentile: 0.9,
+		MaxHedges:  1,
+		MinSamples: 5,
+		WindowSize: 10,
+	}
+
+	next := mocks.NewMockOperatorRequester(ctrl)
+	requester, err := operatorrequester.NewHedgingRequester(logger, next, policy)
+	require.NoError(t, err)
+
+	// learn the operator's usual latency
+	next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(0), types.BlockNum(100), data).DoAndReturn(
+		func(context.Context, types.OperatorAvsState, types.TaskIndex, types.BlockNum, []byte) (*pb.CertifyResponse, error) {
+			time.Sleep(5 * time.Millisecond)
+			return &pb.CertifyResponse{Data: data}, nil
+		},
+	).Times(policy.MinSamples)
+	for i := 0; i < policy.MinSamples; i++ {
+		_, err := requester.RequestCertification(context.Background(), operator, 0, 100, data)
+		require.NoError(t, err)
+	}
+
+	// the first request hangs, the hedged one answers
+	slowCancelled := make(chan struct{})
+	gomock.InOrder(
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(1), types.BlockNum(100), data).DoAndReturn(
+			func(ctx context.Context, _ types.OperatorAvsState, _ types.TaskIndex, _ types.BlockNum, _ []byte) (*pb.CertifyResponse, error) {
+				<-ctx.Done()
+				close(slowCancelled)
+				return nil, status.FromContextError(ctx.Err()).Err()
+			},
+		),
+		next.EXPECT().RequestCertification(gomock.Any(), operator, types.TaskIndex(1), types.BlockNum(100), data).Return(&pb.CertifyResponse{Data: data}, nil),
+	)
+
+	start := time.Now()
+	resp, err := requester.RequestCertification(context.Background(), operator, 1, 100, data)
+	assert.NoError(t, err)
+	assert.Equal(t, data, resp.Data)
+	assert.Less(t, time.Since(start), time.Second)
+
+	select {
+	case <-slowCancelled:
+	case <-time.After(time.Second):
+		t.Fatal("losing request was not cancelled")
+	}
+}
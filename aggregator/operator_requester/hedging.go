@@ -0,0 +1,172 @@
+package operatorrequester
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	pb "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+// HedgingPolicy decides when a request to a slow operator is sent again
+type HedgingPolicy struct {
+	// Percentile of the operator's recent latencies after which another request is sent, in (0, 1]
+	Percentile float64
+	// MaxHedges is the maximum number of requests sent in addition to the first one
+	MaxHedges int
+	// MinSamples is the number of latencies that must be known about an operator before hedging
+	MinSamples int
+	// WindowSize is the number of recent latencies kept per operator
+	WindowSize int
+}
+
+// DefaultHedgingPolicy sends a second request once an operator is slower than 95% of its recent requests
+var DefaultHedgingPolicy = HedgingPolicy{
+	Percentile: 0.95,
+	MaxHedges:  1,
+	MinSamples: 20,
+	WindowSize: 100,
+}
+
+func (p HedgingPolicy) Validate() error {
+	if p.Percentile <= 0 || p.Percentile > 1 {
+		return fmt.Errorf("percentile must be in (0, 1]")
+	}
+	if p.MaxHedges < 0 {
+		return fmt.Errorf("max hedges must not be negative")
+	}
+	if p.MinSamples < 1 || p.WindowSize < p.MinSamples {
+		return fmt.Errorf("window size must be at least min samples, which must be at least 1")
+	}
+	return nil
+}
+
+// latencyWindow holds the most recent latencies of an operator
+type latencyWindow struct {
+	latencies []time.Duration
+	next      int
+}
+
+type hedgingRequester struct {
+	logger logging.Logger
+	next   OperatorRequester
+	policy HedgingPolicy
+
+	mu        sync.Mutex
+	latencies map[types.OperatorId]*latencyWindow
+}
+
+// NewHedgingRequester sends additional requests to operators that haven't answered within
+// the configured percentile of their recent latencies and returns the first successful response
+func NewHedgingRequester(logger logging.Logger, next OperatorRequester, policy HedgingPolicy) (OperatorRequester, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid hedging policy: %w", err)
+	}
+	return &hedgingRequester{
+		logger:    logger,
+		next:      next,
+		policy:    policy,
+		latencies: make(map[types.OperatorId]*latencyWindow),
+	}, nil
+}
+
+type hedgedResult struct {
+	resp    *pb.CertifyResponse
+	err     error
+	latency time.Duration
+}
+
+func (h *hedgingRequester) RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, referenceBlockNumber types.BlockNum, requestData []byte) (*pb.CertifyResponse, error) {
+	delay, ok := h.hedgeDelay(operator.OperatorId)
+	if !ok || h.policy.MaxHedges == 0 {
+		start := time.Now()
+		resp, err := h.next.RequestCertification(ctx, operator, taskIndex, referenceBlockNumber, requestData)
+		if err == nil {
+			h.recordLatency(operator.OperatorId, time.Since(start))
+		}
+		return resp, err
+	}
+
+	// the requests that lost the race are cancelled
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgedResult, h.policy.MaxHedges+1)
+	send := func() {
+		go func() {
+			start := time.Now()
+			resp, err := h.next.RequestCertification(ctx, operator, taskIndex, referenceBlockNumber, requestData)
+			results <- hedgedResult{resp: resp, err: err, latency: time.Since(start)}
+		}()
+	}
+
+	send()
+	inFlight, hedges := 1, 0
+	hedgeTimer := time.NewTimer(delay)
+	defer hedgeTimer.Stop()
+	for {
+		select {
+		case result := <-results:
+			inFlight--
+			if result.err == nil {
+				h.recordLatency(operator.OperatorId, result.latency)
+				return result.resp, nil
+			}
+			// failed requests are left to the retry policy, only slow ones are hedged
+			if inFlight == 0 {
+				return nil, result.err
+			}
+		case <-hedgeTimer.C:
+			h.logger.Debug("Hedging request to slow operator",
+				"operatorId", operator.OperatorId,
+				"taskIndex", taskIndex,
+				"delay", delay)
+			send()
+			inFlight++
+			hedges++
+			if hedges < h.policy.MaxHedges {
+				hedgeTimer.Reset(delay)
+			}
+		}
+	}
+}
+
+// hedgeDelay returns the configured percentile of the operator's recent latencies,
+// or false if not enough of them are known
+func (h *hedgingRequester) hedgeDelay(operatorId types.OperatorId) (time.Duration, bool) {
+	h.mu.Lock()
+	window, ok := h.latencies[operatorId]
+	if !ok || len(window.latencies) < h.policy.MinSamples {
+		h.mu.Unlock()
+		return 0, false
+	}
+	latencies := make([]time.Duration, len(window.latencies))
+	copy(latencies, window.latencies)
+	h.mu.Unlock()
+
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	index := int(math.Ceil(h.policy.Percentile*float64(len(latencies)))) - 1
+	return latencies[max(index, 0)], true
+}
+
+func (h *hedgingRequester) recordLatency(operatorId types.OperatorId, latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	window, ok := h.latencies[operatorId]
+	if !ok {
+		window = &latencyWindow{latencies: make([]time.Duration, 0, h.policy.WindowSize)}
+		h.latencies[operatorId] = window
+	}
+	if len(window.latencies) < h.policy.WindowSize {
+		window.latencies = append(window.latencies, latency)
+		return
+	}
+	window.latencies[window.next] = latency
+	window.next = (window.next + 1) % h.policy.WindowSize
+}
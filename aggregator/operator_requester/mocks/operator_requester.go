@@ -22,7 +22,6 @@ import (
 type MockOperatorRequester struct {
 	ctrl     *gomock.Controller
 	recorder *MockOperatorRequesterMockRecorder
-	isgomock struct{}
 }
 
 // MockOperatorRequesterMockRecorder is the mock recorder for MockOperatorRequester.
@@ -43,16 +42,16 @@ func (m *MockOperatorRequester) EXPECT() *MockOperatorRequesterMockRecorder {
 }
 
 // RequestCertification mocks base method.
-func (m *MockOperatorRequester) RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex uint32, requestData []byte) (*v1.CertifyResponse, error) {
+func (m *MockOperatorRequester) RequestCertification(arg0 context.Context, arg1 types.OperatorAvsState, arg2, arg3 uint32, arg4 []byte) (*v1.CertifyResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RequestCertification", ctx, operator, taskIndex, requestData)
+	ret := m.ctrl.Call(m, "RequestCertification", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*v1.CertifyResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // RequestCertification indicates an expected call of RequestCertification.
-func (mr *MockOperatorRequesterMockRecorder) RequestCertification(ctx, operator, taskIndex, requestData any) *gomock.Call {
+func (mr *MockOperatorRequesterMockRecorder) RequestCertification(arg0, arg1, arg2, arg3, arg4 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCertification", reflect.TypeOf((*MockOperatorRequester)(nil).RequestCertification), ctx, operator, taskIndex, requestData)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequestCertification", reflect.TypeOf((*MockOperatorRequester)(nil).RequestCertification), arg0, arg1, arg2, arg3, arg4)
 }
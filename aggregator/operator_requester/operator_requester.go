@@ -2,33 +2,101 @@ package operatorrequester
 
 import (
 	"context"
+	"crypto/ecdsa"
 
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/types"
 	pb "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/common"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 type OperatorRequester interface {
-	RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, requestData []byte) (*pb.CertifyResponse, error)
+	RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, referenceBlockNumber types.BlockNum, requestData []byte) (*pb.CertifyResponse, error)
 }
 
 type operatorRequester struct {
-	logger logging.Logger
+	logger      logging.Logger
+	connections *ConnectionManager
+	credentials credentials.TransportCredentials
+	signingKey  *ecdsa.PrivateKey
+	tracing     trace.TracerProvider
 }
 
-func NewOperatorRequester(logger logging.Logger) OperatorRequester {
-	return &operatorRequester{
-		logger: logger,
+// Option configures optional behaviour of the operator requester
+type Option func(*operatorRequester)
+
+// WithConnectionManager sends requests over the connections of connections, which
+// the caller is responsible for closing. Without it, connections are kept open for
+// DefaultIdleTimeout and live as long as the process.
+func WithConnectionManager(connections *ConnectionManager) Option {
+	return func(or *operatorRequester) {
+		or.connections = connections
+	}
+}
+
+// WithTLS connects to operators over TLS with creds, see common.NewClientCredentials.
+// It has no effect on the connections of a connection manager passed with WithConnectionManager.
+func WithTLS(creds credentials.TransportCredentials) Option {
+	return func(or *operatorRequester) {
+		or.credentials = creds
 	}
 }
 
-func (or *operatorRequester) RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, requestData []byte) (*pb.CertifyResponse, error) {
-	conn, err := grpc.NewClient(
-		operator.OperatorInfo.Socket.String(),
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+// WithSigningKey signs every request with key, so that nodes can authenticate the aggregator
+func WithSigningKey(key *ecdsa.PrivateKey) Option {
+	return func(or *operatorRequester) {
+		or.signingKey = key
+	}
+}
+
+// WithTracerProvider records the requests to operators as spans of provider instead of
+// the global tracer provider. It has no effect on the connections of a connection manager
+// passed with WithConnectionManager, which should be created with TracingDialOption.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(or *operatorRequester) {
+		or.tracing = provider
+	}
+}
+
+// TracingDialOption records the requests sent over a connection as spans of provider, or
+// of the global tracer provider if it is nil, and propagates their trace context to the
+// operator in the W3C trace context gRPC metadata
+func TracingDialOption(provider trace.TracerProvider) grpc.DialOption {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return grpc.WithStatsHandler(otelgrpc.NewClientHandler(
+		otelgrpc.WithTracerProvider(provider),
+		otelgrpc.WithPropagators(common.TracePropagator),
+	))
+}
+
+func NewOperatorRequester(logger logging.Logger, opts ...Option) OperatorRequester {
+	or := &operatorRequester{
+		logger:      logger,
+		credentials: insecure.NewCredentials(),
+	}
+	for _, opt := range opts {
+		opt(or)
+	}
+	if or.connections == nil {
+		or.connections = NewConnectionManager(
+			DefaultIdleTimeout,
+			grpc.WithTransportCredentials(or.credentials),
+			TracingDialOption(or.tracing),
+		)
+	}
+	return or
+}
+
+func (or *operatorRequester) RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, referenceBlockNumber types.BlockNum, requestData []byte) (*pb.CertifyResponse, error) {
+	conn, release, err := or.connections.Acquire(operator)
 	if err != nil {
 		or.logger.Error("Failed to connect to operator",
 			"operatorId", operator.OperatorId,
@@ -36,15 +104,23 @@ func (or *operatorRequester) RequestCertification(ctx context.Context, operator
 			"error", err)
 		return nil, err
 	}
-	defer conn.Close()
+	defer release()
 
 	client := pb.NewNodeServiceClient(conn)
 
+	req := &pb.CertifyRequest{
+		TaskIndex:            uint32(taskIndex),
+		ReferenceBlockNumber: uint32(referenceBlockNumber),
+		Data:                 requestData,
+	}
+	if or.signingKey != nil {
+		if err := common.SignCertifyRequest(req, or.signingKey); err != nil {
+			return nil, err
+		}
+	}
+
 	// Send task to node
-	resp, err := client.Certify(ctx, &pb.CertifyRequest{
-		TaskIndex: uint32(taskIndex),
-		Data:      requestData,
-	})
+	resp, err := client.Certify(ctx, req)
 	if err != nil {
 		or.logger.Error("Failed to send task to node",
 			"operatorId", operator.OperatorId,
@@ -0,0 +1,129 @@
+package operatorrequester
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	pb "github.com/Layr-Labs/teal/api/service/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy decides how failed requests to an operator are retried
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of requests sent, including the first one
+	MaxAttempts int
+	// InitialBackoff is the delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+	// BackoffMultiplier is applied to the delay after every retry
+	BackoffMultiplier float64
+	// RetryableCodes are the gRPC status codes a request is retried on
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetryPolicy retries transient failures twice
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       3,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        2 * time.Second,
+	BackoffMultiplier: 2,
+	RetryableCodes:    []codes.Code{codes.Unavailable, codes.ResourceExhausted, codes.Aborted},
+}
+
+func (p RetryPolicy) Validate() error {
+	if p.MaxAttempts < 1 {
+		return fmt.Errorf("max attempts must be at least 1")
+	}
+	if p.InitialBackoff < 0 || p.MaxBackoff < p.InitialBackoff {
+		return fmt.Errorf("backoff must satisfy 0 <= initial backoff <= max backoff")
+	}
+	if p.BackoffMultiplier < 1 {
+		return fmt.Errorf("backoff multiplier must be at least 1")
+	}
+	return nil
+}
+
+func (p RetryPolicy) retryable(err error) bool {
+	code := status.Code(err)
+	for _, retryableCode := range p.RetryableCodes {
+		if code == retryableCode {
+			return true
+		}
+	}
+	return false
+}
+
+// retryDelay returns the delay an operator asked to wait before retrying, as set by the
+// google.rpc.RetryInfo detail of its error, or 0
+func retryDelay(err error) time.Duration {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0
+	}
+	for _, detail := range st.Details() {
+		if retryInfo, ok := detail.(*errdetails.RetryInfo); ok {
+			return retryInfo.GetRetryDelay().AsDuration()
+		}
+	}
+	return 0
+}
+
+type retryingRequester struct {
+	logger logging.Logger
+	next   OperatorRequester
+	policy RetryPolicy
+}
+
+// NewRetryingRequester retries the requests of next that fail with a retryable code.
+// Operators can delay retries beyond the backoff with a google.rpc.RetryInfo error detail.
+// A retry is only attempted if its delay ends before the deadline of the request's context.
+func NewRetryingRequester(logger logging.Logger, next OperatorRequester, policy RetryPolicy) (OperatorRequester, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid retry policy: %w", err)
+	}
+	return &retryingRequester{
+		logger: logger,
+		next:   next,
+		policy: policy,
+	}, nil
+}
+
+func (r *retryingRequester) RequestCertification(ctx context.Context, operator types.OperatorAvsState, taskIndex types.TaskIndex, referenceBlockNumber types.BlockNum, requestData []byte) (*pb.CertifyResponse, error) {
+	backoff := r.policy.InitialBackoff
+	for attempt := 1; ; attempt++ {
+		resp, err := r.next.RequestCertification(ctx, operator, taskIndex, referenceBlockNumber, requestData)
+		if err == nil || attempt >= r.policy.MaxAttempts || !r.policy.retryable(err) {
+			return resp, err
+		}
+		delay := max(backoff, retryDelay(err))
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+			return resp, err
+		}
+
+		r.logger.Debug("Retrying request to operator",
+			"operatorId", operator.OperatorId,
+			"taskIndex", taskIndex,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err)
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, err
+		}
+
+		backoff = time.Duration(float64(backoff) * r.policy.BackoffMultiplier)
+		if backoff > r.policy.MaxBackoff {
+			backoff = r.policy.MaxBackoff
+		}
+	}
+}
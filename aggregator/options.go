@@ -0,0 +1,92 @@
+package aggregator
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/teal/aggregator/store"
+	"github.com/Layr-Labs/teal/common"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Option configures optional behaviour of the AggregatorService
+type Option func(*AggregatorService)
+
+// WithCertificateStore persists every certificate produced by the service
+func WithCertificateStore(certificateStore store.CertificateStore) Option {
+	return func(s *AggregatorService) {
+		s.certificateStore = certificateStore
+	}
+}
+
+// WithTaskRegistry allocates task indices from and records task metadata in registry.
+// Without it, an in-memory registry is used and task indices restart from 0 with the process.
+func WithTaskRegistry(registry store.TaskRegistry) Option {
+	return func(s *AggregatorService) {
+		s.taskRegistry = registry
+	}
+}
+
+// WithDefaultTaskPolicy sets the policy used by tasks that don't specify their own.
+// DefaultTaskPolicy is used otherwise.
+func WithDefaultTaskPolicy(policy TaskPolicy) Option {
+	return func(s *AggregatorService) {
+		s.defaultPolicy = policy
+	}
+}
+
+// WithLegacyDigest aggregates operators signing the legacy digest keccak256(response)
+// instead of the digests of the signing domain, the bls aggregation service must be
+// created with common.Keccak256HashFn. Their signatures are valid for any deployment
+// certifying the same response, so they can be replayed on other chains and verifiers.
+func WithLegacyDigest() Option {
+	return func(s *AggregatorService) {
+		s.hashFunction = common.Keccak256HashFn
+	}
+}
+
+// WithDivergenceHandler calls handler for every task whose leading response was signed by
+// less than minLeadingStakeShare (between 0 and 1) of the stake of the responding operators
+func WithDivergenceHandler(minLeadingStakeShare float64, handler DivergenceHandler) Option {
+	return func(s *AggregatorService) {
+		s.minLeadingStakeShare = minLeadingStakeShare
+		s.divergenceHandler = handler
+	}
+}
+
+// WithMetrics records the tasks certified by the service and how operators took part in them in metrics
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *AggregatorService) {
+		s.metrics = metrics
+	}
+}
+
+// WithOperatorTimeout bounds every request to an operator, including its retries, by
+// timeout, so that a hung operator doesn't hold a connection for the whole lifetime of the
+// task. Operators that miss it are reported with OutcomeTimeout. Tasks that expire
+// earlier bound their requests by their expiry. Without it, requests are only bounded by
+// the expiry of their task.
+func WithOperatorTimeout(timeout time.Duration) Option {
+	return func(s *AggregatorService) {
+		s.operatorTimeout = timeout
+	}
+}
+
+// WithSelectionStrategy sends tasks first to the operators chosen by strategy. The other
+// operators are contacted as well once secondWaveDelay passed, or once the first wave
+// answered, without the thresholds being met. A secondWaveDelay of 0 only waits for the
+// first wave. In WaitForAllResponsive mode, the signatures of the first wave are
+// aggregated once it answered. Without it, tasks are sent to all operators at once.
+func WithSelectionStrategy(strategy SelectionStrategy, secondWaveDelay time.Duration) Option {
+	return func(s *AggregatorService) {
+		s.selectionStrategy = strategy
+		s.secondWaveDelay = secondWaveDelay
+	}
+}
+
+// WithTracerProvider records the spans of the service with provider instead of the
+// global tracer provider
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *AggregatorService) {
+		s.tracer = provider.Tracer(TracerName)
+	}
+}
@@ -0,0 +1,179 @@
+package aggregator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// OutcomeClass classifies how an operator took part in a task
+type OutcomeClass int
+
+const (
+	// OutcomePending means the operator had not answered when the task completed
+	OutcomePending OutcomeClass = iota
+	// OutcomeSigned means the operator's signature was verified and aggregated
+	OutcomeSigned
+	// OutcomeDivergentResponse means the operator signed a different response than the one certified
+	OutcomeDivergentResponse
+	// OutcomeTimeout means the request to the operator exceeded its deadline
+	OutcomeTimeout
+	// OutcomeCanceled means the request to the operator was canceled
+	OutcomeCanceled
+	// OutcomeUnreachable means no connection could be established to the operator
+	OutcomeUnreachable
+	// OutcomeRpcError means the operator answered with a gRPC error
+	OutcomeRpcError
+	// OutcomeMalformedSignature means the signature returned by the operator could not be decoded
+	OutcomeMalformedSignature
+	// OutcomeRejectedSignature means the bls aggregation service rejected the signature,
+	// for example because it does not verify against the operator's public key
+	OutcomeRejectedSignature
+	// OutcomeNotContacted means the operator was left out of the first wave of the
+	// selection strategy, and the task completed without contacting it
+	OutcomeNotContacted
+)
+
+func (c OutcomeClass) String() string {
+	switch c {
+	case OutcomePending:
+		return "pending"
+	case OutcomeSigned:
+		return "signed"
+	case OutcomeDivergentResponse:
+		return "divergent_response"
+	case OutcomeTimeout:
+		return "timeout"
+	case OutcomeCanceled:
+		return "canceled"
+	case OutcomeUnreachable:
+		return "unreachable"
+	case OutcomeRpcError:
+		return "rpc_error"
+	case OutcomeMalformedSignature:
+		return "malformed_signature"
+	case OutcomeRejectedSignature:
+		return "rejected_signature"
+	case OutcomeNotContacted:
+		return "not_contacted"
+	default:
+		return fmt.Sprintf("OutcomeClass(%d)", int(c))
+	}
+}
+
+// OperatorOutcome reports how a single operator took part in a task
+type OperatorOutcome struct {
+	OperatorId types.OperatorId
+	Socket     types.Socket
+	Class      OutcomeClass
+	// Latency is the time the operator took to answer, 0 if it is still pending
+	Latency time.Duration
+	// Err is the error the request or signature failed with, if any
+	Err error
+	// ResponseDigest is the digest of the response the operator signed, zero if it didn't answer
+	ResponseDigest types.TaskResponseDigest
+}
+
+// TaskResult is the outcome of a task. The embedded aggregation response is nil if no
+// certificate was produced, in which case Operators still tells what each operator did.
+type TaskResult struct {
+	*blsagg.BlsAggregationServiceResponse
+	// Operators holds one outcome per operator of the task's quorums, ordered by operator id
+	Operators []OperatorOutcome
+	// Divergence groups the verified responses by digest
+	Divergence *DivergenceSummary
+}
+
+// outcomeCollector records the outcome of every operator of a task as requests complete
+type outcomeCollector struct {
+	mu       sync.Mutex
+	outcomes map[types.OperatorId]*OperatorOutcome
+}
+
+func newOutcomeCollector(operators map[types.OperatorId]types.OperatorAvsState) *outcomeCollector {
+	outcomes := make(map[types.OperatorId]*OperatorOutcome, len(operators))
+	for operatorId, operator := range operators {
+		outcomes[operatorId] = &OperatorOutcome{
+			OperatorId: operatorId,
+			Socket:     operator.OperatorInfo.Socket,
+			Class:      OutcomePending,
+		}
+	}
+	return &outcomeCollector{outcomes: outcomes}
+}
+
+func (c *outcomeCollector) record(
+	operatorId types.OperatorId,
+	class OutcomeClass,
+	latency time.Duration,
+	err error,
+	responseDigest types.TaskResponseDigest,
+) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	outcome, ok := c.outcomes[operatorId]
+	if !ok {
+		return
+	}
+	outcome.Class = class
+	outcome.Latency = latency
+	outcome.Err = err
+	outcome.ResponseDigest = responseDigest
+}
+
+// snapshot returns the outcomes recorded so far. Signed responses that differ from the
+// certified response are reported as divergent.
+func (c *outcomeCollector) snapshot(resp *blsagg.BlsAggregationServiceResponse) []OperatorOutcome {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	outcomes := make([]OperatorOutcome, 0, len(c.outcomes))
+	for _, outcome := range c.outcomes {
+		o := *outcome
+		if o.Class == OutcomeSigned && resp != nil && o.ResponseDigest != resp.TaskResponseDigest {
+			o.Class = OutcomeDivergentResponse
+		}
+		outcomes = append(outcomes, o)
+	}
+	sort.Slice(outcomes, func(i, j int) bool {
+		return bytes.Compare(outcomes[i].OperatorId[:], outcomes[j].OperatorId[:]) < 0
+	})
+	return outcomes
+}
+
+// classifyRequestError maps an error returned by the operator requester to an outcome class
+func classifyRequestError(err error) OutcomeClass {
+	if errors.Is(err, errMalformedSignature) {
+		return OutcomeMalformedSignature
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return OutcomeTimeout
+	}
+	if errors.Is(err, context.Canceled) {
+		return OutcomeCanceled
+	}
+	switch status.Code(err) {
+	case codes.DeadlineExceeded:
+		return OutcomeTimeout
+	case codes.Canceled:
+		return OutcomeCanceled
+	case codes.Unavailable:
+		return OutcomeUnreachable
+	case codes.Unknown:
+		// errors that don't carry a gRPC status were raised before the request was sent
+		if _, ok := status.FromError(err); !ok {
+			return OutcomeUnreachable
+		}
+	}
+	return OutcomeRpcError
+}
@@ -0,0 +1,83 @@
+package aggregator
+
+import (
+	"fmt"
+	"time"
+)
+
+// AggregationMode decides when a task returns once its quorum thresholds are met
+type AggregationMode int
+
+const (
+	// WaitForWindow keeps collecting signatures for the policy's Window after the thresholds are met
+	WaitForWindow AggregationMode = iota
+	// ReturnAtThreshold returns as soon as the thresholds are met. This gives the lowest
+	// latency but certificates with more non-signers, which are more expensive to verify on-chain.
+	ReturnAtThreshold
+	// WaitForAllResponsive waits until every operator has either responded or failed before
+	// aggregating, so that the certificate contains as few non-signers as possible. The
+	// collected signatures are aggregated at the latest a Window before the task expires.
+	WaitForAllResponsive
+)
+
+func (m AggregationMode) String() string {
+	switch m {
+	case WaitForWindow:
+		return "WaitForWindow"
+	case ReturnAtThreshold:
+		return "ReturnAtThreshold"
+	case WaitForAllResponsive:
+		return "WaitForAllResponsive"
+	default:
+		return fmt.Sprintf("AggregationMode(%d)", int(m))
+	}
+}
+
+// TaskPolicy configures how long a task keeps collecting signatures
+type TaskPolicy struct {
+	Mode AggregationMode
+	// Window is the time signatures are still accepted after the thresholds are met.
+	// It is ignored in ReturnAtThreshold mode. In WaitForAllResponsive mode it is the
+	// time given to aggregate the collected signatures, and must not be 0.
+	Window time.Duration
+}
+
+// DefaultTaskPolicy collects signatures for one more second after the thresholds are met
+var DefaultTaskPolicy = TaskPolicy{
+	Mode:   WaitForWindow,
+	Window: 1 * time.Second,
+}
+
+func (p TaskPolicy) Validate() error {
+	switch p.Mode {
+	case WaitForWindow, ReturnAtThreshold:
+	case WaitForAllResponsive:
+		if p.Window == 0 {
+			return fmt.Errorf("window must be set in %s mode", p.Mode)
+		}
+	default:
+		return fmt.Errorf("unknown aggregation mode %s", p.Mode)
+	}
+	if p.Window < 0 {
+		return fmt.Errorf("window must not be negative")
+	}
+	return nil
+}
+
+// ValidateExpiry checks that the policy leaves a task expiring after timeToExpiry time to
+// collect signatures. In WaitForAllResponsive mode, a window that is not shorter than the
+// time to expiry would aggregate the signatures as soon as the task starts.
+func (p TaskPolicy) ValidateExpiry(timeToExpiry time.Duration) error {
+	if p.Mode == WaitForAllResponsive && p.Window >= timeToExpiry {
+		return fmt.Errorf("window %s must be shorter than the time to expiry %s in %s mode", p.Window, timeToExpiry, p.Mode)
+	}
+	return nil
+}
+
+// aggregationWindow is the window passed to the bls aggregation service
+func (p TaskPolicy) aggregationWindow() time.Duration {
+	if p.Mode == ReturnAtThreshold {
+		return 0
+	}
+	return p.Window
+}
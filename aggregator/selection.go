@@ -0,0 +1,207 @@
+package aggregator
+
+import (
+	"math/big"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+)
+
+// SelectionStrategy chooses the operators a task is sent to first. The other operators
+// of the task's quorums form a second wave, which is only contacted if the first wave
+// doesn't meet the task's thresholds in time, see WithSelectionStrategy.
+type SelectionStrategy interface {
+	// SelectOperators returns the operators of the first wave. quorums holds the total
+	// stake of each of the task's quorums.
+	SelectOperators(
+		task *Task,
+		operators map[types.OperatorId]types.OperatorAvsState,
+		quorums map[types.QuorumNum]types.QuorumAvsState,
+	) []types.OperatorId
+}
+
+// SelectionStrategyFunc adapts a function to a SelectionStrategy
+type SelectionStrategyFunc func(
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) []types.OperatorId
+
+func (f SelectionStrategyFunc) SelectOperators(
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) []types.OperatorId {
+	return f(task, operators, quorums)
+}
+
+// AllOperators sends every task to all operators of its quorums at once
+func AllOperators() SelectionStrategy {
+	return SelectionStrategyFunc(func(
+		_ *Task,
+		operators map[types.OperatorId]types.OperatorAvsState,
+		_ map[types.QuorumNum]types.QuorumAvsState,
+	) []types.OperatorId {
+		return sortedOperatorIds(operators)
+	})
+}
+
+// TopNByStake sends tasks to the n operators with the most stake first. Operators are
+// ranked by the sum of their shares of the total stake of the task's quorums.
+func TopNByStake(n int) SelectionStrategy {
+	return SelectionStrategyFunc(func(
+		task *Task,
+		operators map[types.OperatorId]types.OperatorAvsState,
+		quorums map[types.QuorumNum]types.QuorumAvsState,
+	) []types.OperatorId {
+		operatorIds := sortedOperatorIds(operators)
+		shares := make(map[types.OperatorId]float64, len(operators))
+		for _, operatorId := range operatorIds {
+			shares[operatorId] = stakeShare(operators[operatorId], task.QuorumNumbers, quorums)
+		}
+		// the stable sort keeps operators with the same share ordered by id
+		sort.SliceStable(operatorIds, func(i, j int) bool {
+			return shares[operatorIds[i]] > shares[operatorIds[j]]
+		})
+		if n < len(operatorIds) {
+			operatorIds = operatorIds[:max(n, 0)]
+		}
+		return operatorIds
+	})
+}
+
+// StakeWeightedRandom sends tasks first to operators drawn at random, with a probability
+// proportional to their stake, until the drawn operators hold marginPercentage points more
+// than the threshold of the total stake of every quorum. Spreading tasks this way evens out
+// the load of the operators while leaving room for some of them to fail.
+func StakeWeightedRandom(marginPercentage uint8) SelectionStrategy {
+	return &stakeWeightedRandom{
+		marginPercentage: marginPercentage,
+		rand:             rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+type stakeWeightedRandom struct {
+	marginPercentage uint8
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func (s *stakeWeightedRandom) SelectOperators(
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) []types.OperatorId {
+	remaining := sortedOperatorIds(operators)
+	weights := make([]float64, len(remaining))
+	for i, operatorId := range remaining {
+		weights[i] = stakeShare(operators[operatorId], task.QuorumNumbers, quorums)
+	}
+
+	selectedStake := make(map[types.QuorumNum]*big.Int, len(task.QuorumNumbers))
+	for _, quorumNumber := range task.QuorumNumbers {
+		selectedStake[quorumNumber] = new(big.Int)
+	}
+	targetMet := func() bool {
+		for i, quorumNumber := range task.QuorumNumbers {
+			quorum, ok := quorums[quorumNumber]
+			if !ok || quorum.TotalStake == nil {
+				return false
+			}
+			target := min(int64(task.QuorumThresholdPercentages[i])+int64(s.marginPercentage), 100)
+			selected := new(big.Int).Mul(selectedStake[quorumNumber], big.NewInt(100))
+			required := new(big.Int).Mul(quorum.TotalStake, big.NewInt(target))
+			if selected.Cmp(required) < 0 {
+				return false
+			}
+		}
+		return true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var selected []types.OperatorId
+	for len(remaining) > 0 && !targetMet() {
+		var totalWeight float64
+		for _, weight := range weights {
+			totalWeight += weight
+		}
+		// operators without stake are only drawn once all operators with stake were
+		i := s.rand.Intn(len(remaining))
+		if totalWeight > 0 {
+			draw := s.rand.Float64() * totalWeight
+			for i = 0; i < len(weights)-1 && draw >= weights[i]; i++ {
+				draw -= weights[i]
+			}
+		}
+
+		operatorId := remaining[i]
+		selected = append(selected, operatorId)
+		for _, quorumNumber := range task.QuorumNumbers {
+			if stake := operators[operatorId].StakePerQuorum[quorumNumber]; stake != nil {
+				selectedStake[quorumNumber].Add(selectedStake[quorumNumber], stake)
+			}
+		}
+		remaining = append(remaining[:i], remaining[i+1:]...)
+		weights = append(weights[:i], weights[i+1:]...)
+	}
+	return selected
+}
+
+// stakeShare is the sum of operator's shares of the total stake of quorumNumbers
+func stakeShare(
+	operator types.OperatorAvsState,
+	quorumNumbers types.QuorumNums,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) float64 {
+	var share float64
+	for _, quorumNumber := range quorumNumbers {
+		stake := operator.StakePerQuorum[quorumNumber]
+		quorum, ok := quorums[quorumNumber]
+		if stake == nil || !ok || quorum.TotalStake == nil || quorum.TotalStake.Sign() == 0 {
+			continue
+		}
+		quorumShare, _ := new(big.Rat).SetFrac(stake, quorum.TotalStake).Float64()
+		share += quorumShare
+	}
+	return share
+}
+
+func sortedOperatorIds(operators map[types.OperatorId]types.OperatorAvsState) []types.OperatorId {
+	operatorIds := make([]types.OperatorId, 0, len(operators))
+	for operatorId := range operators {
+		operatorIds = append(operatorIds, operatorId)
+	}
+	sortOperatorIds(operatorIds)
+	return operatorIds
+}
+
+// splitWaves splits operators into the first wave chosen by strategy and the rest
+func splitWaves(
+	strategy SelectionStrategy,
+	task *Task,
+	operators map[types.OperatorId]types.OperatorAvsState,
+	quorums map[types.QuorumNum]types.QuorumAvsState,
+) (firstWave, secondWave []types.OperatorId) {
+	if strategy == nil {
+		return sortedOperatorIds(operators), nil
+	}
+	selected := make(map[types.OperatorId]bool, len(operators))
+	for _, operatorId := range strategy.SelectOperators(task, operators, quorums) {
+		if _, ok := operators[operatorId]; ok && !selected[operatorId] {
+			selected[operatorId] = true
+			firstWave = append(firstWave, operatorId)
+		}
+	}
+	for _, operatorId := range sortedOperatorIds(operators) {
+		if !selected[operatorId] {
+			secondWave = append(secondWave, operatorId)
+		}
+	}
+	return firstWave, secondWave
+}
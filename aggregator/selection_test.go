@@ -0,0 +1,68 @@
+package aggregator_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Layr-Labs/teal/aggregator"
+)
+
+func TestSelectionStrategies(t *testing.T) {
+	stakes := []int64{10, 40, 30, 20}
+	operators := make(map[types.OperatorId]types.OperatorAvsState, len(stakes))
+	for i, stake := range stakes {
+		operatorId := types.OperatorId{byte(i + 1)}
+		operators[operatorId] = types.OperatorAvsState{
+			OperatorId:     operatorId,
+			StakePerQuorum: map[types.QuorumNum]types.StakeAmount{0: big.NewInt(stake)},
+		}
+	}
+	quorums := map[types.QuorumNum]types.QuorumAvsState{
+		0: {QuorumNumber: 0, TotalStake: big.NewInt(100)},
+	}
+	task := &aggregator.Task{TaskRequest: aggregator.TaskRequest{
+		QuorumNumbers:              types.QuorumNums{0},
+		QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+		TimeToExpiry:               time.Second,
+	}}
+
+	t.Run("all operators", func(t *testing.T) {
+		selected := aggregator.AllOperators().SelectOperators(task, operators, quorums)
+		assert.Equal(t, []types.OperatorId{{1}, {2}, {3}, {4}}, selected)
+	})
+
+	t.Run("top n by stake", func(t *testing.T) {
+		selected := aggregator.TopNByStake(2).SelectOperators(task, operators, quorums)
+		assert.Equal(t, []types.OperatorId{{2}, {3}}, selected)
+
+		selected = aggregator.TopNByStake(10).SelectOperators(task, operators, quorums)
+		assert.Equal(t, []types.OperatorId{{2}, {3}, {4}, {1}}, selected)
+	})
+
+	t.Run("stake weighted random", func(t *testing.T) {
+		strategy := aggregator.StakeWeightedRandom(10)
+		for i := 0; i < 100; i++ {
+			selected := strategy.SelectOperators(task, operators, quorums)
+
+			seen := make(map[types.OperatorId]bool)
+			var selectedStake int64
+			for _, operatorId := range selected {
+				assert.False(t, seen[operatorId])
+				seen[operatorId] = true
+				selectedStake += operators[operatorId].StakePerQuorum[0].Int64()
+			}
+			assert.GreaterOrEqual(t, selectedStake, int64(60))
+			// drawing stops as soon as the target is met
+			lastStake := operators[selected[len(selected)-1]].StakePerQuorum[0].Int64()
+			assert.Less(t, selectedStake-lastStake, int64(60))
+		}
+
+		// a target beyond the total stake selects everyone
+		selected := aggregator.StakeWeightedRandom(60).SelectOperators(task, operators, quorums)
+		assert.Len(t, selected, len(operators))
+	})
+}
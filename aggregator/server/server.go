@@ -0,0 +1,109 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"sync"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/teal/aggregator"
+	"github.com/Layr-Labs/teal/aggregator/service"
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+type Config struct {
+	ServicePort int
+	// HttpPort serves the HTTP/JSON gateway, disabled if 0
+	HttpPort int
+}
+
+type Server struct {
+	config  Config
+	service *service.TaskService
+
+	stopC    chan struct{}
+	stopOnce sync.Once
+}
+
+// NewServer creates a server exposing the aggregator over gRPC and HTTP/JSON
+func NewServer(config Config, logger logging.Logger, aggregator *aggregator.AggregatorService) *Server {
+	return &Server{
+		config:  config,
+		service: service.NewTaskService(logger, aggregator),
+		stopC:   make(chan struct{}),
+	}
+}
+
+// Stop stops serving and cancels the tasks submitted to the server
+func (s *Server) Stop() {
+	s.stopOnce.Do(func() { close(s.stopC) })
+}
+
+func (s *Server) Start() error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", s.config.ServicePort))
+	if err != nil {
+		return err
+	}
+
+	var httpLis net.Listener
+	if s.config.HttpPort != 0 {
+		httpLis, err = net.Listen("tcp", fmt.Sprintf(":%d", s.config.HttpPort))
+		if err != nil {
+			lis.Close()
+			return err
+		}
+	}
+	return s.StartWithListeners(lis, httpLis)
+}
+
+// StartWithListeners serves gRPC on lis and, if httpLis is not nil, the HTTP/JSON gateway on httpLis.
+// It returns once serving failed or Stop was called, after the submitted tasks were cancelled.
+func (s *Server) StartWithListeners(lis net.Listener, httpLis net.Listener) error {
+	defer s.service.Close()
+
+	grpcServer := grpc.NewServer()
+	v1.RegisterAggregatorServiceServer(grpcServer, s.service)
+	reflection.Register(grpcServer)
+
+	errC := make(chan error, 2)
+	if httpLis != nil {
+		mux := runtime.NewServeMux()
+		if err := v1.RegisterAggregatorServiceHandlerServer(context.Background(), mux, s.service); err != nil {
+			return err
+		}
+		httpServer := &http.Server{Handler: mux}
+		defer httpServer.Close()
+
+		go func() {
+			log.Printf("Starting HTTP gateway on %s", httpLis.Addr())
+			if err := httpServer.Serve(httpLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Failed to serve HTTP gateway on %s: %v", httpLis.Addr(), err)
+				errC <- err
+			}
+		}()
+	}
+
+	go func() {
+		log.Printf("Starting server on %s", lis.Addr())
+		if err := grpcServer.Serve(lis); err != nil {
+			log.Printf("Failed to serve on %s: %v", lis.Addr(), err)
+			errC <- err
+		}
+	}()
+
+	var err error
+	select {
+	case err = <-errC:
+	case <-s.stopC:
+	}
+	grpcServer.Stop()
+	return err
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+// certificateToProto converts an aggregation response into its wire representation
+func certificateToProto(
+	resp *blsagg.BlsAggregationServiceResponse,
+	referenceBlockNumber uint32,
+	quorumNumbers types.QuorumNums,
+) *v1.Certificate {
+	taskResponse, _ := resp.TaskResponse.([]byte)
+
+	nonSignerStakeIndices := make([]*v1.NonSignerStakeIndices, len(resp.NonSignerStakeIndices))
+	for i, indices := range resp.NonSignerStakeIndices {
+		nonSignerStakeIndices[i] = &v1.NonSignerStakeIndices{Indices: indices}
+	}
+
+	return &v1.Certificate{
+		TaskIndex:                    uint32(resp.TaskIndex),
+		ReferenceBlockNumber:         referenceBlockNumber,
+		QuorumNumbers:                quorumNumbers.UnderlyingType(),
+		TaskResponse:                 taskResponse,
+		TaskResponseDigest:           resp.TaskResponseDigest[:],
+		NonSignersPubkeysG1:          g1PointsToProto(resp.NonSignersPubkeysG1),
+		QuorumApksG1:                 g1PointsToProto(resp.QuorumApksG1),
+		SignersApkG2:                 g2PointToProto(resp.SignersApkG2),
+		SignersAggSigG1:              g1PointToProto(resp.SignersAggSigG1.G1Point),
+		NonSignerQuorumBitmapIndices: resp.NonSignerQuorumBitmapIndices,
+		QuorumApkIndices:             resp.QuorumApkIndices,
+		TotalStakeIndices:            resp.TotalStakeIndices,
+		NonSignerStakeIndices:        nonSignerStakeIndices,
+	}
+}
+
+func g1PointsToProto(ps []*bls.G1Point) []*v1.G1Point {
+	points := make([]*v1.G1Point, len(ps))
+	for i, p := range ps {
+		points[i] = g1PointToProto(p)
+	}
+	return points
+}
+
+func g1PointToProto(p *bls.G1Point) *v1.G1Point {
+	x := p.X.Bytes()
+	y := p.Y.Bytes()
+	return &v1.G1Point{X: x[:], Y: y[:]}
+}
+
+func g2PointToProto(p *bls.G2Point) *v1.G2Point {
+	xA0 := p.X.A0.Bytes()
+	xA1 := p.X.A1.Bytes()
+	yA0 := p.Y.A0.Bytes()
+	yA1 := p.Y.A1.Bytes()
+	return &v1.G2Point{XA0: xA0[:], XA1: xA1[:], YA0: yA0[:], YA1: yA1[:]}
+}
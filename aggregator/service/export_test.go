@@ -0,0 +1,9 @@
+package service
+
+// TrackedTasks returns the number of tasks whose result is not persisted yet
+func (s *TaskService) TrackedTasks() int {
+	s.tasksMu.RLock()
+	defer s.tasksMu.RUnlock()
+
+	return len(s.tasks)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"github.com/Layr-Labs/eigensdk-go/types"
+
+	"github.com/Layr-Labs/teal/aggregator"
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+func operatorOutcomesToProto(outcomes []aggregator.OperatorOutcome) []*v1.OperatorOutcome {
+	operators := make([]*v1.OperatorOutcome, len(outcomes))
+	for i, outcome := range outcomes {
+		operator := &v1.OperatorOutcome{
+			OperatorId: outcome.OperatorId[:],
+			Socket:     outcome.Socket.String(),
+			Class:      outcomeClassToProto(outcome.Class),
+			LatencyMs:  uint64(outcome.Latency.Milliseconds()),
+		}
+		if outcome.Err != nil {
+			operator.Error = outcome.Err.Error()
+		}
+		if outcome.ResponseDigest != (types.TaskResponseDigest{}) {
+			operator.ResponseDigest = outcome.ResponseDigest[:]
+		}
+		operators[i] = operator
+	}
+	return operators
+}
+
+func outcomeClassToProto(class aggregator.OutcomeClass) v1.OutcomeClass {
+	switch class {
+	case aggregator.OutcomePending:
+		return v1.OutcomeClass_OUTCOME_CLASS_PENDING
+	case aggregator.OutcomeSigned:
+		return v1.OutcomeClass_OUTCOME_CLASS_SIGNED
+	case aggregator.OutcomeDivergentResponse:
+		return v1.OutcomeClass_OUTCOME_CLASS_DIVERGENT_RESPONSE
+	case aggregator.OutcomeTimeout:
+		return v1.OutcomeClass_OUTCOME_CLASS_TIMEOUT
+	case aggregator.OutcomeCanceled:
+		return v1.OutcomeClass_OUTCOME_CLASS_CANCELED
+	case aggregator.OutcomeUnreachable:
+		return v1.OutcomeClass_OUTCOME_CLASS_UNREACHABLE
+	case aggregator.OutcomeRpcError:
+		return v1.OutcomeClass_OUTCOME_CLASS_RPC_ERROR
+	case aggregator.OutcomeMalformedSignature:
+		return v1.OutcomeClass_OUTCOME_CLASS_MALFORMED_SIGNATURE
+	case aggregator.OutcomeRejectedSignature:
+		return v1.OutcomeClass_OUTCOME_CLASS_REJECTED_SIGNATURE
+	case aggregator.OutcomeNotContacted:
+		return v1.OutcomeClass_OUTCOME_CLASS_NOT_CONTACTED
+	default:
+		return v1.OutcomeClass_OUTCOME_CLASS_UNSPECIFIED
+	}
+}
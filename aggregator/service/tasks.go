@@ -0,0 +1,351 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/Layr-Labs/teal/aggregator"
+	"github.com/Layr-Labs/teal/aggregator/store"
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+// task tracks a task submitted to this service until its result is persisted
+type task struct {
+	referenceBlockNumber uint32
+	quorumNumbers        types.QuorumNums
+
+	// done is closed once result or err is set
+	done   chan struct{}
+	result *aggregator.TaskResult
+	err    error
+}
+
+// DefaultResultRetention is how long the results of tasks are kept in memory when
+// the aggregator has no certificate store, unless WithResultRetention is used
+const DefaultResultRetention = 10 * time.Minute
+
+type TaskService struct {
+	logger          logging.Logger
+	aggregator      *aggregator.AggregatorService
+	resultRetention time.Duration
+
+	// ctx is the parent of the contexts of all submitted tasks, it is cancelled by Close
+	ctx     context.Context
+	cancel  context.CancelFunc
+	running sync.WaitGroup
+
+	tasks   map[types.TaskIndex]*task
+	tasksMu sync.RWMutex
+
+	v1.UnsafeAggregatorServiceServer
+}
+
+type Option func(*TaskService)
+
+// WithResultRetention keeps the results of finished tasks in memory for retention when the
+// aggregator has no certificate store. Afterwards only their status is available from the task registry.
+func WithResultRetention(retention time.Duration) Option {
+	return func(s *TaskService) {
+		s.resultRetention = retention
+	}
+}
+
+func NewTaskService(logger logging.Logger, aggregator *aggregator.AggregatorService, opts ...Option) *TaskService {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &TaskService{
+		logger:          logger,
+		aggregator:      aggregator,
+		resultRetention: DefaultResultRetention,
+		ctx:             ctx,
+		cancel:          cancel,
+		tasks:           make(map[types.TaskIndex]*task),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Close cancels the tasks that are still running and waits for them to finish
+func (s *TaskService) Close() {
+	// tasksMu orders the cancellation with the tasks being started
+	s.tasksMu.Lock()
+	s.cancel()
+	s.tasksMu.Unlock()
+	s.running.Wait()
+}
+
+func (s *TaskService) SubmitTask(ctx context.Context, req *v1.SubmitTaskRequest) (*v1.SubmitTaskResponse, error) {
+	quorumNumbers := make(types.QuorumNums, len(req.QuorumNumbers))
+	for i, quorumNumber := range req.QuorumNumbers {
+		quorumNumbers[i] = types.QuorumNum(quorumNumber)
+	}
+	quorumThresholdPercentages := make(types.QuorumThresholdPercentages, len(req.QuorumThresholdPercentages))
+	for i, quorumThresholdPercentage := range req.QuorumThresholdPercentages {
+		quorumThresholdPercentages[i] = types.QuorumThresholdPercentage(quorumThresholdPercentage)
+	}
+	if err := aggregator.ValidateQuorums(quorumNumbers, quorumThresholdPercentages); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid quorums: %v", err)
+	}
+	if req.TimeToExpiryMs == 0 {
+		return nil, status.Error(codes.InvalidArgument, "time to expiry must be set")
+	}
+
+	policy, err := taskPolicyFromProto(req.Policy)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid task policy: %v", err)
+	}
+	timeToExpiry := time.Duration(req.TimeToExpiryMs) * time.Millisecond
+	if policy != nil {
+		if err := policy.ValidateExpiry(timeToExpiry); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid task policy: %v", err)
+		}
+	}
+	operatorTimeout := time.Duration(req.OperatorTimeoutMs) * time.Millisecond
+	if err := aggregator.ValidateOperatorTimeout(operatorTimeout, timeToExpiry); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid operator timeout: %v", err)
+	}
+
+	s.tasksMu.Lock()
+	if s.ctx.Err() != nil {
+		s.tasksMu.Unlock()
+		return nil, status.Error(codes.Unavailable, "the service is shutting down")
+	}
+	s.running.Add(1)
+	s.tasksMu.Unlock()
+
+	aggregatorTask, err := s.aggregator.CreateTask(ctx, aggregator.TaskRequest{
+		ReferenceBlockNumber:       req.ReferenceBlockNumber,
+		QuorumNumbers:              quorumNumbers,
+		QuorumThresholdPercentages: quorumThresholdPercentages,
+		Data:                       req.Data,
+		TimeToExpiry:               timeToExpiry,
+		Policy:                     policy,
+		OperatorTimeout:            operatorTimeout,
+	})
+	if err != nil {
+		s.running.Done()
+		return nil, status.Errorf(codes.Internal, "failed to create task: %v", err)
+	}
+
+	t := &task{
+		referenceBlockNumber: req.ReferenceBlockNumber,
+		quorumNumbers:        quorumNumbers,
+		done:                 make(chan struct{}),
+	}
+	s.tasksMu.Lock()
+	s.tasks[aggregatorTask.TaskIndex] = t
+	s.tasksMu.Unlock()
+
+	// the task outlives the request, so it must not inherit its context
+	go func() {
+		defer s.running.Done()
+		defer close(t.done)
+		t.result, t.err = s.aggregator.CertifyTask(s.ctx, aggregatorTask)
+		if t.err != nil {
+			s.logger.Error("Failed to get certificate", "taskIndex", aggregatorTask.TaskIndex, "error", t.err)
+		}
+		// once persisted, the result is read from the certificate store and task registry.
+		// Without a certificate store, the certificate is only available from memory for a while.
+		if t.err != nil || s.aggregator.CertificateStore() != nil {
+			s.forgetTask(aggregatorTask.TaskIndex)
+			return
+		}
+		time.AfterFunc(s.resultRetention, func() { s.forgetTask(aggregatorTask.TaskIndex) })
+	}()
+
+	return &v1.SubmitTaskResponse{TaskIndex: uint32(aggregatorTask.TaskIndex)}, nil
+}
+
+func (s *TaskService) GetTask(ctx context.Context, req *v1.GetTaskRequest) (*v1.GetTaskResponse, error) {
+	record, err := s.aggregator.TaskRegistry().GetTask(ctx, types.TaskIndex(req.TaskIndex))
+	if errors.Is(err, store.ErrTaskNotFound) {
+		return nil, status.Errorf(codes.NotFound, "task %d not found", req.TaskIndex)
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get task: %v", err)
+	}
+
+	return &v1.GetTaskResponse{
+		TaskIndex:                  uint32(record.TaskIndex),
+		Status:                     taskStatusToProto(record.Status),
+		CreatedAt:                  timestamppb.New(record.CreatedAt),
+		ReferenceBlockNumber:       record.ReferenceBlockNumber,
+		QuorumNumbers:              record.QuorumNumbers.UnderlyingType(),
+		QuorumThresholdPercentages: record.QuorumThresholdPercentages.UnderlyingType(),
+		DataHash:                   record.DataHash.Bytes(),
+		Error:                      record.Error,
+	}, nil
+}
+
+func (s *TaskService) GetCertificate(ctx context.Context, req *v1.GetCertificateRequest) (*v1.GetCertificateResponse, error) {
+	taskIndex := types.TaskIndex(req.TaskIndex)
+	t, ok := s.getTask(taskIndex)
+	if !ok {
+		// finished tasks and tasks submitted before a restart are only available from the certificate store and task registry
+		taskStatus, certificate, errMsg, err := s.getPersistedResult(ctx, taskIndex)
+		if err != nil {
+			return nil, err
+		}
+		return &v1.GetCertificateResponse{Status: taskStatus, Certificate: certificate, Error: errMsg}, nil
+	}
+
+	select {
+	case <-t.done:
+	default:
+		return &v1.GetCertificateResponse{Status: v1.TaskStatus_TASK_STATUS_PENDING}, nil
+	}
+
+	taskStatus, certificate, errMsg, operators := t.outcome()
+	return &v1.GetCertificateResponse{Status: taskStatus, Certificate: certificate, Error: errMsg, Operators: operators}, nil
+}
+
+func (s *TaskService) WaitForCertificate(ctx context.Context, req *v1.WaitForCertificateRequest) (*v1.WaitForCertificateResponse, error) {
+	taskIndex := types.TaskIndex(req.TaskIndex)
+	t, ok := s.getTask(taskIndex)
+	if !ok {
+		// finished tasks and tasks that were not submitted through this service can't be waited on, report their recorded state
+		taskStatus, certificate, errMsg, err := s.getPersistedResult(ctx, taskIndex)
+		if err != nil {
+			return nil, err
+		}
+		return &v1.WaitForCertificateResponse{Status: taskStatus, Certificate: certificate, Error: errMsg}, nil
+	}
+
+	select {
+	case <-t.done:
+	case <-ctx.Done():
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+
+	taskStatus, certificate, errMsg, operators := t.outcome()
+	return &v1.WaitForCertificateResponse{Status: taskStatus, Certificate: certificate, Error: errMsg, Operators: operators}, nil
+}
+
+func (s *TaskService) ListCertificates(ctx context.Context, req *v1.ListCertificatesRequest) (*v1.ListCertificatesResponse, error) {
+	certificateStore := s.aggregator.CertificateStore()
+	if certificateStore == nil {
+		return nil, status.Error(codes.Unimplemented, "no certificate store configured")
+	}
+
+	var certificates []*store.Certificate
+	var err error
+	switch filter := req.Filter.(type) {
+	case *v1.ListCertificatesRequest_TaskResponseDigest:
+		if len(filter.TaskResponseDigest) != len(types.TaskResponseDigest{}) {
+			return nil, status.Error(codes.InvalidArgument, "task response digest must be 32 bytes")
+		}
+		certificates, err = certificateStore.GetByResponseDigest(ctx, types.TaskResponseDigest(filter.TaskResponseDigest))
+	case *v1.ListCertificatesRequest_CreatedAt:
+		if filter.CreatedAt.From == nil || filter.CreatedAt.To == nil {
+			return nil, status.Error(codes.InvalidArgument, "time range must have both from and to set")
+		}
+		certificates, err = certificateStore.GetByTimeRange(ctx, filter.CreatedAt.From.AsTime(), filter.CreatedAt.To.AsTime())
+	default:
+		return nil, status.Error(codes.InvalidArgument, "a filter must be set")
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to query certificates: %v", err)
+	}
+
+	resp := &v1.ListCertificatesResponse{Certificates: make([]*v1.Certificate, len(certificates))}
+	for i, certificate := range certificates {
+		resp.Certificates[i] = certificateToProto(certificate.Response, certificate.ReferenceBlockNumber, certificate.QuorumNumbers)
+	}
+	return resp, nil
+}
+
+// getPersistedResult looks up the outcome of a task that is not tracked in memory
+func (s *TaskService) getPersistedResult(ctx context.Context, taskIndex types.TaskIndex) (v1.TaskStatus, *v1.Certificate, string, error) {
+	if certificateStore := s.aggregator.CertificateStore(); certificateStore != nil {
+		certificate, err := certificateStore.GetByTaskIndex(ctx, taskIndex)
+		if err == nil {
+			return v1.TaskStatus_TASK_STATUS_COMPLETED, certificateToProto(certificate.Response, certificate.ReferenceBlockNumber, certificate.QuorumNumbers), "", nil
+		}
+	}
+
+	record, err := s.aggregator.TaskRegistry().GetTask(ctx, taskIndex)
+	if err != nil {
+		return v1.TaskStatus_TASK_STATUS_UNSPECIFIED, nil, "", status.Errorf(codes.NotFound, "task %d not found", taskIndex)
+	}
+	return taskStatusToProto(record.Status), nil, record.Error, nil
+}
+
+func (s *TaskService) getTask(taskIndex types.TaskIndex) (*task, bool) {
+	s.tasksMu.RLock()
+	defer s.tasksMu.RUnlock()
+
+	t, ok := s.tasks[taskIndex]
+	return t, ok
+}
+
+func (s *TaskService) forgetTask(taskIndex types.TaskIndex) {
+	s.tasksMu.Lock()
+	defer s.tasksMu.Unlock()
+
+	delete(s.tasks, taskIndex)
+}
+
+// outcome must only be called once t.done is closed
+func (t *task) outcome() (v1.TaskStatus, *v1.Certificate, string, []*v1.OperatorOutcome) {
+	var operators []*v1.OperatorOutcome
+	if t.result != nil {
+		operators = operatorOutcomesToProto(t.result.Operators)
+	}
+	if t.err != nil {
+		return v1.TaskStatus_TASK_STATUS_FAILED, nil, t.err.Error(), operators
+	}
+	return v1.TaskStatus_TASK_STATUS_COMPLETED, certificateToProto(t.result.BlsAggregationServiceResponse, t.referenceBlockNumber, t.quorumNumbers), "", operators
+}
+
+// taskPolicyFromProto returns nil if the aggregator's default policy should be used
+func taskPolicyFromProto(policy *v1.TaskPolicy) (*aggregator.TaskPolicy, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	var mode aggregator.AggregationMode
+	switch policy.Mode {
+	case v1.AggregationMode_AGGREGATION_MODE_UNSPECIFIED:
+		return nil, nil
+	case v1.AggregationMode_AGGREGATION_MODE_WAIT_FOR_WINDOW:
+		mode = aggregator.WaitForWindow
+	case v1.AggregationMode_AGGREGATION_MODE_RETURN_AT_THRESHOLD:
+		mode = aggregator.ReturnAtThreshold
+	case v1.AggregationMode_AGGREGATION_MODE_WAIT_FOR_ALL_RESPONSIVE:
+		mode = aggregator.WaitForAllResponsive
+	default:
+		return nil, fmt.Errorf("unknown aggregation mode %s", policy.Mode)
+	}
+
+	taskPolicy := &aggregator.TaskPolicy{
+		Mode:   mode,
+		Window: time.Duration(policy.WindowMs) * time.Millisecond,
+	}
+	if err := taskPolicy.Validate(); err != nil {
+		return nil, err
+	}
+	return taskPolicy, nil
+}
+
+func taskStatusToProto(taskStatus store.TaskStatus) v1.TaskStatus {
+	switch taskStatus {
+	case store.TaskStatusPending:
+		return v1.TaskStatus_TASK_STATUS_PENDING
+	case store.TaskStatusCompleted:
+		return v1.TaskStatus_TASK_STATUS_COMPLETED
+	case store.TaskStatusFailed:
+		return v1.TaskStatus_TASK_STATUS_FAILED
+	default:
+		return v1.TaskStatus_TASK_STATUS_UNSPECIFIED
+	}
+}
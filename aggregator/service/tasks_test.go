@@ -0,0 +1,247 @@
+package service_test
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/services/avsregistry"
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/testutils"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/teal/aggregator"
+	mockOperatorRequester "github.com/Layr-Labs/teal/aggregator/operator_requester/mocks"
+	"github.com/Layr-Labs/teal/aggregator/service"
+	"github.com/Layr-Labs/teal/aggregator/store"
+	pb "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTaskService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	fakeOperatorRequester := mockOperatorRequester.NewMockOperatorRequester(ctrl)
+
+	testOperator1 := types.TestOperator{
+		OperatorId: types.OperatorId{1},
+		StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+			0: big.NewInt(100),
+		},
+		BlsKeypair: newBlsKeyPairPanics("0x1"),
+	}
+	blockNum := uint32(1)
+	requestData := []byte("test 1")
+
+	logger := testutils.GetTestLogger()
+	fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1})
+	blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+	aggregatorService := aggregator.NewAggregatorService(
+		logger,
+		fakeAvsRegistryService,
+		blsAggService,
+		fakeOperatorRequester,
+		testDomain,
+		aggregator.WithCertificateStore(store.NewInMemoryCertificateStore()),
+	)
+	taskService := service.NewTaskService(logger, aggregatorService)
+
+	t.Run("submit and wait for certificate", func(t *testing.T) {
+		ctx := context.Background()
+		taskIndex := types.TaskIndex(0)
+
+		digest := testDomain.Digest(uint32(taskIndex), blockNum, requestData)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), gomock.Any(), taskIndex, gomock.Any(), requestData).Return(&pb.CertifyResponse{
+			Signature: testOperator1.BlsKeypair.SignMessage(digest).Marshal(),
+			Data:      requestData,
+		}, nil)
+
+		submitResp, err := taskService.SubmitTask(ctx, &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             uint64((5 * time.Second).Milliseconds()),
+		})
+		require.NoError(t, err)
+		assert.Equal(t, uint32(taskIndex), submitResp.TaskIndex)
+
+		waitResp, err := taskService.WaitForCertificate(ctx, &pb.WaitForCertificateRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_COMPLETED, waitResp.Status)
+		assert.Equal(t, requestData, waitResp.Certificate.TaskResponse)
+		assert.Equal(t, digest[:], waitResp.Certificate.TaskResponseDigest)
+		assert.Equal(t, []byte{0}, waitResp.Certificate.QuorumNumbers)
+		assert.Len(t, waitResp.Certificate.QuorumApksG1, 1)
+
+		// the task is no longer tracked in memory once its certificate is stored
+		assert.Eventually(t, func() bool { return taskService.TrackedTasks() == 0 }, time.Second, 10*time.Millisecond)
+
+		getResp, err := taskService.GetCertificate(ctx, &pb.GetCertificateRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_COMPLETED, getResp.Status)
+		assert.Equal(t, waitResp.Certificate, getResp.Certificate)
+
+		taskResp, err := taskService.GetTask(ctx, &pb.GetTaskRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_COMPLETED, taskResp.Status)
+		assert.Equal(t, crypto.Keccak256(requestData), taskResp.DataHash)
+	})
+
+	t.Run("invalid and unknown tasks", func(t *testing.T) {
+		ctx := context.Background()
+
+		_, err := taskService.SubmitTask(ctx, &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0, 1},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             1000,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = taskService.SubmitTask(ctx, &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             1000,
+			OperatorTimeoutMs:          1000,
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = taskService.SubmitTask(ctx, &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             1000,
+			Policy:                     &pb.TaskPolicy{Mode: pb.AggregationMode_AGGREGATION_MODE_WAIT_FOR_ALL_RESPONSIVE, WindowMs: 1000},
+		})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = taskService.GetCertificate(ctx, &pb.GetCertificateRequest{TaskIndex: 42})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+
+		_, err = taskService.GetTask(ctx, &pb.GetTaskRequest{TaskIndex: 42})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestTaskServiceLifecycle(t *testing.T) {
+	testOperator1 := types.TestOperator{
+		OperatorId: types.OperatorId{1},
+		StakePerQuorum: map[types.QuorumNum]types.StakeAmount{
+			0: big.NewInt(100),
+		},
+		BlsKeypair: newBlsKeyPairPanics("0x1"),
+	}
+	blockNum := uint32(1)
+	requestData := []byte("test 1")
+	logger := testutils.GetTestLogger()
+
+	// newTaskService returns a service whose aggregator has no certificate store
+	newTaskService := func(t *testing.T, opts ...service.Option) (*service.TaskService, *mockOperatorRequester.MockOperatorRequester) {
+		fakeOperatorRequester := mockOperatorRequester.NewMockOperatorRequester(gomock.NewController(t))
+		fakeAvsRegistryService := avsregistry.NewFakeAvsRegistryService(blockNum, []types.TestOperator{testOperator1})
+		blsAggService := blsagg.NewBlsAggregatorService(fakeAvsRegistryService, testDomain.HashFn(), logger)
+		aggregatorService := aggregator.NewAggregatorService(logger, fakeAvsRegistryService, blsAggService, fakeOperatorRequester, testDomain)
+		return service.NewTaskService(logger, aggregatorService, opts...), fakeOperatorRequester
+	}
+	submitTask := func(t *testing.T, taskService *service.TaskService) types.TaskIndex {
+		submitResp, err := taskService.SubmitTask(context.Background(), &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             uint64((5 * time.Second).Milliseconds()),
+		})
+		require.NoError(t, err)
+		return types.TaskIndex(submitResp.TaskIndex)
+	}
+
+	t.Run("results without a certificate store are kept for the retention", func(t *testing.T) {
+		ctx := context.Background()
+		taskService, fakeOperatorRequester := newTaskService(t, service.WithResultRetention(200*time.Millisecond))
+		defer taskService.Close()
+
+		digest := testDomain.Digest(0, blockNum, requestData)
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), gomock.Any(), types.TaskIndex(0), gomock.Any(), requestData).Return(&pb.CertifyResponse{
+			Signature: testOperator1.BlsKeypair.SignMessage(digest).Marshal(),
+			Data:      requestData,
+		}, nil)
+		taskIndex := submitTask(t, taskService)
+
+		waitResp, err := taskService.WaitForCertificate(ctx, &pb.WaitForCertificateRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_COMPLETED, waitResp.Status)
+		require.NotNil(t, waitResp.Certificate)
+		assert.Equal(t, 1, taskService.TrackedTasks())
+
+		assert.Eventually(t, func() bool { return taskService.TrackedTasks() == 0 }, time.Second, 10*time.Millisecond)
+		getResp, err := taskService.GetCertificate(ctx, &pb.GetCertificateRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_COMPLETED, getResp.Status)
+		assert.Nil(t, getResp.Certificate)
+	})
+
+	t.Run("close cancels running tasks", func(t *testing.T) {
+		ctx := context.Background()
+		taskService, fakeOperatorRequester := newTaskService(t)
+
+		requested := make(chan struct{})
+		fakeOperatorRequester.EXPECT().RequestCertification(gomock.Any(), gomock.Any(), types.TaskIndex(0), gomock.Any(), requestData).DoAndReturn(
+			func(ctx context.Context, _ any, _ types.TaskIndex, _ any, _ []byte) (*pb.CertifyResponse, error) {
+				close(requested)
+				<-ctx.Done()
+				return nil, ctx.Err()
+			})
+		taskIndex := submitTask(t, taskService)
+		<-requested
+
+		closed := make(chan struct{})
+		go func() {
+			taskService.Close()
+			close(closed)
+		}()
+		select {
+		case <-closed:
+		case <-time.After(3 * time.Second):
+			t.Fatal("close did not cancel the running task")
+		}
+
+		taskResp, err := taskService.GetTask(ctx, &pb.GetTaskRequest{TaskIndex: uint32(taskIndex)})
+		require.NoError(t, err)
+		assert.Equal(t, pb.TaskStatus_TASK_STATUS_FAILED, taskResp.Status)
+
+		_, err = taskService.SubmitTask(ctx, &pb.SubmitTaskRequest{
+			ReferenceBlockNumber:       blockNum,
+			QuorumNumbers:              []byte{0},
+			QuorumThresholdPercentages: []byte{100},
+			Data:                       requestData,
+			TimeToExpiryMs:             1000,
+		})
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+}
+
+// testDomain is the signing domain of the tests' operators
+var testDomain = common.SigningDomain{
+	ChainId:         big.NewInt(31337),
+	VerifierAddress: gethcommon.HexToAddress("0x4242424242424242424242424242424242424242"),
+}
+
+func newBlsKeyPairPanics(hexKey string) *bls.KeyPair {
+	keypair, err := bls.NewKeyPairFromString(hexKey)
+	if err != nil {
+		panic(err)
+	}
+	return keypair
+}
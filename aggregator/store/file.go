@@ -0,0 +1,429 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const certificateFileSuffix = ".json"
+
+// FileCertificateStore keeps one JSON file per certificate in a directory.
+// All certificates are loaded on startup and queries are served from memory.
+type FileCertificateStore struct {
+	dir   string
+	index *InMemoryCertificateStore
+	// mu serializes Put, so that a certificate on disk is never replaced by another one
+	// for the same task that passed the check for an existing certificate concurrently
+	mu sync.Mutex
+}
+
+var _ CertificateStore = (*FileCertificateStore)(nil)
+
+// NewFileCertificateStore opens the store in dir, creating the directory if needed
+func NewFileCertificateStore(dir string) (*FileCertificateStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create certificate directory: %w", err)
+	}
+
+	s := &FileCertificateStore{
+		dir:   dir,
+		index: NewInMemoryCertificateStore(),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read certificate directory: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), certificateFileSuffix) {
+			continue
+		}
+		raw, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read certificate %s: %w", entry.Name(), err)
+		}
+		var stored storedCertificate
+		if err := json.Unmarshal(raw, &stored); err != nil {
+			return nil, fmt.Errorf("failed to decode certificate %s: %w", entry.Name(), err)
+		}
+		certificate, err := stored.toCertificate()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode certificate %s: %w", entry.Name(), err)
+		}
+		if err := s.index.Put(context.Background(), certificate); err != nil {
+			return nil, fmt.Errorf("failed to load certificate %s: %w", entry.Name(), err)
+		}
+	}
+	return s, nil
+}
+
+func (s *FileCertificateStore) Put(ctx context.Context, certificate *Certificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, err := s.index.GetByTaskIndex(ctx, certificate.TaskIndex); err == nil {
+		return ErrAlreadyExists
+	}
+
+	raw, err := json.MarshalIndent(newStoredCertificate(certificate), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode certificate: %w", err)
+	}
+
+	// write to a temporary file first so that a crash never leaves a partial certificate behind
+	path := filepath.Join(s.dir, fmt.Sprintf("%d%s", certificate.TaskIndex, certificateFileSuffix))
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, raw, 0o644); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to write certificate: %w", err)
+	}
+
+	return s.index.Put(ctx, certificate)
+}
+
+func (s *FileCertificateStore) GetByTaskIndex(ctx context.Context, taskIndex types.TaskIndex) (*Certificate, error) {
+	return s.index.GetByTaskIndex(ctx, taskIndex)
+}
+
+func (s *FileCertificateStore) GetByResponseDigest(ctx context.Context, digest types.TaskResponseDigest) ([]*Certificate, error) {
+	return s.index.GetByResponseDigest(ctx, digest)
+}
+
+func (s *FileCertificateStore) GetByTimeRange(ctx context.Context, from time.Time, to time.Time) ([]*Certificate, error) {
+	return s.index.GetByTimeRange(ctx, from, to)
+}
+
+// storedCertificate is the on-disk representation of a Certificate
+type storedCertificate struct {
+	TaskIndex                    uint32          `json:"task_index"`
+	ReferenceBlockNumber         uint32          `json:"reference_block_number"`
+	QuorumNumbers                hexutil.Bytes   `json:"quorum_numbers"`
+	QuorumThresholdPercentages   hexutil.Bytes   `json:"quorum_threshold_percentages"`
+	RequestData                  hexutil.Bytes   `json:"request_data"`
+	TaskResponse                 hexutil.Bytes   `json:"task_response"`
+	TaskResponseDigest           hexutil.Bytes   `json:"task_response_digest"`
+	NonSignersPubkeysG1          []hexutil.Bytes `json:"non_signers_pubkeys_g1"`
+	QuorumApksG1                 []hexutil.Bytes `json:"quorum_apks_g1"`
+	SignersApkG2                 hexutil.Bytes   `json:"signers_apk_g2"`
+	SignersAggSigG1              hexutil.Bytes   `json:"signers_agg_sig_g1"`
+	NonSignerQuorumBitmapIndices []uint32        `json:"non_signer_quorum_bitmap_indices"`
+	QuorumApkIndices             []uint32        `json:"quorum_apk_indices"`
+	TotalStakeIndices            []uint32        `json:"total_stake_indices"`
+	NonSignerStakeIndices        [][]uint32      `json:"non_signer_stake_indices"`
+	Signers                      []hexutil.Bytes `json:"signers"`
+	NonSigners                   []hexutil.Bytes `json:"non_signers"`
+	CreatedAt                    time.Time       `json:"created_at"`
+}
+
+func newStoredCertificate(c *Certificate) *storedCertificate {
+	taskResponse, _ := c.Response.TaskResponse.([]byte)
+	return &storedCertificate{
+		TaskIndex:                    uint32(c.TaskIndex),
+		ReferenceBlockNumber:         c.ReferenceBlockNumber,
+		QuorumNumbers:                c.QuorumNumbers.UnderlyingType(),
+		QuorumThresholdPercentages:   c.QuorumThresholdPercentages.UnderlyingType(),
+		RequestData:                  c.RequestData,
+		TaskResponse:                 taskResponse,
+		TaskResponseDigest:           c.Response.TaskResponseDigest[:],
+		NonSignersPubkeysG1:          serializeG1Points(c.Response.NonSignersPubkeysG1),
+		QuorumApksG1:                 serializeG1Points(c.Response.QuorumApksG1),
+		SignersApkG2:                 c.Response.SignersApkG2.Serialize(),
+		SignersAggSigG1:              c.Response.SignersAggSigG1.Serialize(),
+		NonSignerQuorumBitmapIndices: c.Response.NonSignerQuorumBitmapIndices,
+		QuorumApkIndices:             c.Response.QuorumApkIndices,
+		TotalStakeIndices:            c.Response.TotalStakeIndices,
+		NonSignerStakeIndices:        c.Response.NonSignerStakeIndices,
+		Signers:                      serializeOperatorIds(c.Signers),
+		NonSigners:                   serializeOperatorIds(c.NonSigners),
+		CreatedAt:                    c.CreatedAt,
+	}
+}
+
+func (s *storedCertificate) toCertificate() (*Certificate, error) {
+	if len(s.TaskResponseDigest) != len(types.TaskResponseDigest{}) {
+		return nil, fmt.Errorf("invalid task response digest length %d", len(s.TaskResponseDigest))
+	}
+	signers, err := deserializeOperatorIds(s.Signers)
+	if err != nil {
+		return nil, err
+	}
+	nonSigners, err := deserializeOperatorIds(s.NonSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Certificate{
+		TaskIndex:                  types.TaskIndex(s.TaskIndex),
+		ReferenceBlockNumber:       s.ReferenceBlockNumber,
+		QuorumNumbers:              toQuorumNumbers(s.QuorumNumbers),
+		QuorumThresholdPercentages: toQuorumThresholdPercentages(s.QuorumThresholdPercentages),
+		RequestData:                s.RequestData,
+		Response: &blsagg.BlsAggregationServiceResponse{
+			TaskIndex:                    types.TaskIndex(s.TaskIndex),
+			TaskResponse:                 []byte(s.TaskResponse),
+			TaskResponseDigest:           types.TaskResponseDigest(s.TaskResponseDigest),
+			NonSignersPubkeysG1:          deserializeG1Points(s.NonSignersPubkeysG1),
+			QuorumApksG1:                 deserializeG1Points(s.QuorumApksG1),
+			SignersApkG2:                 new(bls.G2Point).Deserialize(s.SignersApkG2),
+			SignersAggSigG1:              &bls.Signature{G1Point: new(bls.G1Point).Deserialize(s.SignersAggSigG1)},
+			NonSignerQuorumBitmapIndices: s.NonSignerQuorumBitmapIndices,
+			QuorumApkIndices:             s.QuorumApkIndices,
+			TotalStakeIndices:            s.TotalStakeIndices,
+			NonSignerStakeIndices:        s.NonSignerStakeIndices,
+		},
+		Signers:    signers,
+		NonSigners: nonSigners,
+		CreatedAt:  s.CreatedAt,
+	}, nil
+}
+
+func serializeG1Points(ps []*bls.G1Point) []hexutil.Bytes {
+	serialized := make([]hexutil.Bytes, len(ps))
+	for i, p := range ps {
+		serialized[i] = p.Serialize()
+	}
+	return serialized
+}
+
+func deserializeG1Points(serialized []hexutil.Bytes) []*bls.G1Point {
+	ps := make([]*bls.G1Point, len(serialized))
+	for i, s := range serialized {
+		ps[i] = new(bls.G1Point).Deserialize(s)
+	}
+	return ps
+}
+
+func serializeOperatorIds(operatorIds []types.OperatorId) []hexutil.Bytes {
+	serialized := make([]hexutil.Bytes, len(operatorIds))
+	for i, operatorId := range operatorIds {
+		serialized[i] = operatorId[:]
+	}
+	return serialized
+}
+
+func deserializeOperatorIds(serialized []hexutil.Bytes) ([]types.OperatorId, error) {
+	operatorIds := make([]types.OperatorId, len(serialized))
+	for i, s := range serialized {
+		if len(s) != len(types.OperatorId{}) {
+			return nil, fmt.Errorf("invalid operator id length %d", len(s))
+		}
+		operatorIds[i] = types.OperatorId(s)
+	}
+	return operatorIds, nil
+}
+
+// FileTaskRegistry keeps an append-only journal of task records, one JSON object per line.
+// The latest record of a task wins when the journal is replayed on startup.
+type FileTaskRegistry struct {
+	file  *os.File
+	index *InMemoryTaskRegistry
+	mu    sync.Mutex
+}
+
+var _ TaskRegistry = (*FileTaskRegistry)(nil)
+
+// NewFileTaskRegistry opens the journal at path, creating it if needed. Tasks that were
+// still pending when the journal was last written can never complete and are marked as failed.
+// A last record that can't be decoded was cut off by a crash while it was written, it is
+// dropped with a warning.
+func NewFileTaskRegistry(logger logging.Logger, path string) (*FileTaskRegistry, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create task registry directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open task registry: %w", err)
+	}
+
+	r := &FileTaskRegistry{
+		file:  file,
+		index: NewInMemoryTaskRegistry(),
+	}
+	interrupted, err := r.replay(logger)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	for taskIndex, pending := range interrupted {
+		if !pending {
+			continue
+		}
+		if err := r.UpdateTaskStatus(context.Background(), taskIndex, TaskStatusFailed, "interrupted by aggregator restart"); err != nil {
+			file.Close()
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// replay restores the tasks of the journal and reports which of them are still pending
+func (r *FileTaskRegistry) replay(logger logging.Logger) (map[types.TaskIndex]bool, error) {
+	interrupted := map[types.TaskIndex]bool{}
+	reader := bufio.NewReader(r.file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to read task registry: %w", err)
+		}
+		last := errors.Is(err, io.EOF)
+		if len(bytes.TrimSpace(line)) == 0 {
+			if last {
+				return interrupted, nil
+			}
+			offset += int64(len(line))
+			continue
+		}
+
+		var stored storedTaskRecord
+		if err := json.Unmarshal(line, &stored); err != nil {
+			if _, peekErr := reader.Peek(1); !last && !errors.Is(peekErr, io.EOF) {
+				return nil, fmt.Errorf("failed to decode task record: %w", err)
+			}
+			// appending after the partial record would corrupt the next one as well
+			logger.Warn("Dropping a partially written task record", "path", r.file.Name(), "offset", offset, "error", err)
+			if err := r.file.Truncate(offset); err != nil {
+				return nil, fmt.Errorf("failed to truncate task registry: %w", err)
+			}
+			return interrupted, nil
+		}
+		task := stored.toTaskRecord()
+		r.index.restore(task)
+		interrupted[task.TaskIndex] = task.Status == TaskStatusPending
+
+		if last {
+			// terminate the record, so that the next one starts on a line of its own
+			if _, err := r.file.Write([]byte{'\n'}); err != nil {
+				return nil, fmt.Errorf("failed to write task registry: %w", err)
+			}
+			return interrupted, nil
+		}
+		offset += int64(len(line))
+	}
+}
+
+func (r *FileTaskRegistry) CreateTask(ctx context.Context, task TaskRecord) (*TaskRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	created, err := r.index.CreateTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.append(created); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+func (r *FileTaskRegistry) UpdateTaskStatus(ctx context.Context, taskIndex types.TaskIndex, status TaskStatus, errMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.index.UpdateTaskStatus(ctx, taskIndex, status, errMsg); err != nil {
+		return err
+	}
+	updated, err := r.index.GetTask(ctx, taskIndex)
+	if err != nil {
+		return err
+	}
+	return r.append(updated)
+}
+
+func (r *FileTaskRegistry) GetTask(ctx context.Context, taskIndex types.TaskIndex) (*TaskRecord, error) {
+	return r.index.GetTask(ctx, taskIndex)
+}
+
+func (r *FileTaskRegistry) Close() error {
+	return r.file.Close()
+}
+
+// append must be called with r.mu held
+func (r *FileTaskRegistry) append(task *TaskRecord) error {
+	raw, err := json.Marshal(newStoredTaskRecord(task))
+	if err != nil {
+		return fmt.Errorf("failed to encode task record: %w", err)
+	}
+	if _, err := r.file.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("failed to write task record: %w", err)
+	}
+	// the task index counter must be durable before the index is handed out
+	if err := r.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync task registry: %w", err)
+	}
+	return nil
+}
+
+// storedTaskRecord is the on-disk representation of a TaskRecord
+type storedTaskRecord struct {
+	TaskIndex                  uint32          `json:"task_index"`
+	Status                     TaskStatus      `json:"status"`
+	CreatedAt                  time.Time       `json:"created_at"`
+	ReferenceBlockNumber       uint32          `json:"reference_block_number"`
+	QuorumNumbers              hexutil.Bytes   `json:"quorum_numbers"`
+	QuorumThresholdPercentages hexutil.Bytes   `json:"quorum_threshold_percentages"`
+	DataHash                   gethcommon.Hash `json:"data_hash"`
+	Error                      string          `json:"error,omitempty"`
+}
+
+func newStoredTaskRecord(t *TaskRecord) *storedTaskRecord {
+	return &storedTaskRecord{
+		TaskIndex:                  uint32(t.TaskIndex),
+		Status:                     t.Status,
+		CreatedAt:                  t.CreatedAt,
+		ReferenceBlockNumber:       t.ReferenceBlockNumber,
+		QuorumNumbers:              t.QuorumNumbers.UnderlyingType(),
+		QuorumThresholdPercentages: t.QuorumThresholdPercentages.UnderlyingType(),
+		DataHash:                   t.DataHash,
+		Error:                      t.Error,
+	}
+}
+
+func (s *storedTaskRecord) toTaskRecord() TaskRecord {
+	return TaskRecord{
+		TaskIndex:                  types.TaskIndex(s.TaskIndex),
+		Status:                     s.Status,
+		CreatedAt:                  s.CreatedAt,
+		ReferenceBlockNumber:       s.ReferenceBlockNumber,
+		QuorumNumbers:              toQuorumNumbers(s.QuorumNumbers),
+		QuorumThresholdPercentages: toQuorumThresholdPercentages(s.QuorumThresholdPercentages),
+		DataHash:                   s.DataHash,
+		Error:                      s.Error,
+	}
+}
+
+func toQuorumNumbers(raw []byte) types.QuorumNums {
+	quorumNumbers := make(types.QuorumNums, len(raw))
+	for i, quorumNumber := range raw {
+		quorumNumbers[i] = types.QuorumNum(quorumNumber)
+	}
+	return quorumNumbers
+}
+
+func toQuorumThresholdPercentages(raw []byte) types.QuorumThresholdPercentages {
+	quorumThresholdPercentages := make(types.QuorumThresholdPercentages, len(raw))
+	for i, quorumThresholdPercentage := range raw {
+		quorumThresholdPercentages[i] = types.QuorumThresholdPercentage(quorumThresholdPercentage)
+	}
+	return quorumThresholdPercentages
+}
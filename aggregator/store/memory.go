@@ -0,0 +1,140 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+)
+
+type InMemoryCertificateStore struct {
+	certificates map[types.TaskIndex]*Certificate
+	byDigest     map[types.TaskResponseDigest][]*Certificate
+	mu           sync.RWMutex
+}
+
+var _ CertificateStore = (*InMemoryCertificateStore)(nil)
+
+func NewInMemoryCertificateStore() *InMemoryCertificateStore {
+	return &InMemoryCertificateStore{
+		certificates: make(map[types.TaskIndex]*Certificate),
+		byDigest:     make(map[types.TaskResponseDigest][]*Certificate),
+	}
+}
+
+func (s *InMemoryCertificateStore) Put(ctx context.Context, certificate *Certificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.certificates[certificate.TaskIndex]; ok {
+		return ErrAlreadyExists
+	}
+	s.certificates[certificate.TaskIndex] = certificate
+	digest := certificate.Response.TaskResponseDigest
+	s.byDigest[digest] = append(s.byDigest[digest], certificate)
+	return nil
+}
+
+func (s *InMemoryCertificateStore) GetByTaskIndex(ctx context.Context, taskIndex types.TaskIndex) (*Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	certificate, ok := s.certificates[taskIndex]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return certificate, nil
+}
+
+func (s *InMemoryCertificateStore) GetByResponseDigest(ctx context.Context, digest types.TaskResponseDigest) ([]*Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	certificates := make([]*Certificate, len(s.byDigest[digest]))
+	copy(certificates, s.byDigest[digest])
+	return certificates, nil
+}
+
+func (s *InMemoryCertificateStore) GetByTimeRange(ctx context.Context, from time.Time, to time.Time) ([]*Certificate, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	certificates := []*Certificate{}
+	for _, certificate := range s.certificates {
+		if !certificate.CreatedAt.Before(from) && certificate.CreatedAt.Before(to) {
+			certificates = append(certificates, certificate)
+		}
+	}
+	sort.Slice(certificates, func(i, j int) bool {
+		return certificates[i].CreatedAt.Before(certificates[j].CreatedAt)
+	})
+	return certificates, nil
+}
+
+type InMemoryTaskRegistry struct {
+	nextTaskIndex types.TaskIndex
+	tasks         map[types.TaskIndex]*TaskRecord
+	mu            sync.RWMutex
+}
+
+var _ TaskRegistry = (*InMemoryTaskRegistry)(nil)
+
+func NewInMemoryTaskRegistry() *InMemoryTaskRegistry {
+	return &InMemoryTaskRegistry{
+		tasks: make(map[types.TaskIndex]*TaskRecord),
+	}
+}
+
+func (r *InMemoryTaskRegistry) CreateTask(ctx context.Context, task TaskRecord) (*TaskRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task.TaskIndex = r.nextTaskIndex
+	task.Status = TaskStatusPending
+	r.nextTaskIndex++
+
+	r.tasks[task.TaskIndex] = &task
+	created := task
+	return &created, nil
+}
+
+func (r *InMemoryTaskRegistry) UpdateTaskStatus(ctx context.Context, taskIndex types.TaskIndex, status TaskStatus, errMsg string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, ok := r.tasks[taskIndex]
+	if !ok {
+		return ErrTaskNotFound
+	}
+	task.Status = status
+	task.Error = ""
+	if status == TaskStatusFailed {
+		task.Error = errMsg
+	}
+	return nil
+}
+
+func (r *InMemoryTaskRegistry) GetTask(ctx context.Context, taskIndex types.TaskIndex) (*TaskRecord, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, ok := r.tasks[taskIndex]
+	if !ok {
+		return nil, ErrTaskNotFound
+	}
+	found := *task
+	return &found, nil
+}
+
+// restore adds a task loaded from disk, advancing the task index counter past it
+func (r *InMemoryTaskRegistry) restore(task TaskRecord) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tasks[task.TaskIndex] = &task
+	if task.TaskIndex >= r.nextTaskIndex {
+		r.nextTaskIndex = task.TaskIndex + 1
+	}
+}
@@ -0,0 +1,41 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+)
+
+var (
+	ErrNotFound      = errors.New("certificate not found")
+	ErrAlreadyExists = errors.New("certificate already exists")
+)
+
+// Certificate is the record kept for every certificate produced by the aggregator
+type Certificate struct {
+	TaskIndex                  types.TaskIndex
+	ReferenceBlockNumber       uint32
+	QuorumNumbers              types.QuorumNums
+	QuorumThresholdPercentages types.QuorumThresholdPercentages
+	// RequestData is the data that was sent to the operators
+	RequestData []byte
+	Response    *blsagg.BlsAggregationServiceResponse
+	Signers     []types.OperatorId
+	NonSigners  []types.OperatorId
+	CreatedAt   time.Time
+}
+
+// CertificateStore persists certificates so they survive restarts and failed on-chain submissions
+type CertificateStore interface {
+	// Put stores a certificate, returning ErrAlreadyExists if its task index is already taken
+	Put(ctx context.Context, certificate *Certificate) error
+	// GetByTaskIndex returns ErrNotFound if no certificate exists for taskIndex
+	GetByTaskIndex(ctx context.Context, taskIndex types.TaskIndex) (*Certificate, error)
+	// GetByResponseDigest returns all certificates over the given response digest
+	GetByResponseDigest(ctx context.Context, digest types.TaskResponseDigest) ([]*Certificate, error)
+	// GetByTimeRange returns all certificates created in [from, to), ordered by creation time
+	GetByTimeRange(ctx context.Context, from time.Time, to time.Time) ([]*Certificate, error)
+}
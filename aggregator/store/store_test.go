@@ -0,0 +1,157 @@
+package store_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	blsagg "github.com/Layr-Labs/eigensdk-go/services/bls_aggregation"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/teal/aggregator/store"
+	"github.com/Layr-Labs/teal/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCertificateStores(t *testing.T) {
+	dir := t.TempDir()
+	fileStore, err := store.NewFileCertificateStore(dir)
+	require.NoError(t, err)
+
+	stores := map[string]store.CertificateStore{
+		"in memory": store.NewInMemoryCertificateStore(),
+		"file":      fileStore,
+	}
+
+	now := time.Now().UTC()
+	first := newTestCertificate(1, []byte("response"), now)
+	second := newTestCertificate(2, []byte("response"), now.Add(time.Minute))
+	third := newTestCertificate(3, []byte("other response"), now.Add(2*time.Minute))
+
+	for name, certificateStore := range stores {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			for _, certificate := range []*store.Certificate{first, second, third} {
+				require.NoError(t, certificateStore.Put(ctx, certificate))
+			}
+			assert.ErrorIs(t, certificateStore.Put(ctx, first), store.ErrAlreadyExists)
+
+			certificate, err := certificateStore.GetByTaskIndex(ctx, 2)
+			require.NoError(t, err)
+			assert.Equal(t, second.TaskIndex, certificate.TaskIndex)
+
+			_, err = certificateStore.GetByTaskIndex(ctx, 42)
+			assert.ErrorIs(t, err, store.ErrNotFound)
+
+			certificates, err := certificateStore.GetByResponseDigest(ctx, first.Response.TaskResponseDigest)
+			require.NoError(t, err)
+			assert.Len(t, certificates, 2)
+
+			certificates, err = certificateStore.GetByTimeRange(ctx, now.Add(30*time.Second), now.Add(3*time.Minute))
+			require.NoError(t, err)
+			require.Len(t, certificates, 2)
+			assert.Equal(t, second.TaskIndex, certificates[0].TaskIndex)
+			assert.Equal(t, third.TaskIndex, certificates[1].TaskIndex)
+		})
+	}
+
+	t.Run("file store survives reopening", func(t *testing.T) {
+		reopened, err := store.NewFileCertificateStore(dir)
+		require.NoError(t, err)
+
+		certificate, err := reopened.GetByTaskIndex(context.Background(), first.TaskIndex)
+		require.NoError(t, err)
+		assert.Equal(t, first.ReferenceBlockNumber, certificate.ReferenceBlockNumber)
+		assert.Equal(t, first.QuorumNumbers, certificate.QuorumNumbers)
+		assert.Equal(t, first.QuorumThresholdPercentages, certificate.QuorumThresholdPercentages)
+		assert.Equal(t, first.RequestData, certificate.RequestData)
+		assert.Equal(t, first.Signers, certificate.Signers)
+		assert.Equal(t, first.NonSigners, certificate.NonSigners)
+		assert.True(t, first.CreatedAt.Equal(certificate.CreatedAt))
+		assert.Equal(t, first.Response.TaskResponse, certificate.Response.TaskResponse)
+		assert.Equal(t, first.Response.TaskResponseDigest, certificate.Response.TaskResponseDigest)
+		assert.True(t, first.Response.SignersApkG2.Equal(certificate.Response.SignersApkG2.G2Affine))
+		assert.True(t, first.Response.SignersAggSigG1.Equal(certificate.Response.SignersAggSigG1.G1Affine))
+		assert.True(t, first.Response.NonSignersPubkeysG1[0].Equal(certificate.Response.NonSignersPubkeysG1[0].G1Affine))
+		assert.Equal(t, first.Response.NonSignerStakeIndices, certificate.Response.NonSignerStakeIndices)
+	})
+
+	t.Run("file store keeps one certificate per task under concurrent puts", func(t *testing.T) {
+		dir := t.TempDir()
+		fileStore, err := store.NewFileCertificateStore(dir)
+		require.NoError(t, err)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 50)
+		for i := range errs {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = fileStore.Put(context.Background(), newTestCertificate(1, []byte{byte(i)}, now))
+			}()
+		}
+		wg.Wait()
+
+		var stored []byte
+		for i, err := range errs {
+			if err == nil {
+				require.Nil(t, stored, "more than one put succeeded")
+				stored = []byte{byte(i)}
+			} else {
+				assert.ErrorIs(t, err, store.ErrAlreadyExists)
+			}
+		}
+		require.NotNil(t, stored)
+
+		certificate, err := fileStore.GetByTaskIndex(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, stored, certificate.Response.TaskResponse)
+
+		reopened, err := store.NewFileCertificateStore(dir)
+		require.NoError(t, err)
+		certificate, err = reopened.GetByTaskIndex(context.Background(), 1)
+		require.NoError(t, err)
+		assert.Equal(t, stored, certificate.Response.TaskResponse)
+	})
+}
+
+func newTestCertificate(taskIndex types.TaskIndex, response []byte, createdAt time.Time) *store.Certificate {
+	signer, err := bls.NewKeyPairFromString("0x1")
+	if err != nil {
+		panic(err)
+	}
+	nonSigner, err := bls.NewKeyPairFromString("0x2")
+	if err != nil {
+		panic(err)
+	}
+	digest, err := common.Keccak256HashFn(response)
+	if err != nil {
+		panic(err)
+	}
+
+	return &store.Certificate{
+		TaskIndex:                  taskIndex,
+		ReferenceBlockNumber:       10,
+		QuorumNumbers:              types.QuorumNums{0},
+		QuorumThresholdPercentages: types.QuorumThresholdPercentages{50},
+		RequestData:                []byte("request"),
+		Response: &blsagg.BlsAggregationServiceResponse{
+			TaskIndex:                    taskIndex,
+			TaskResponse:                 response,
+			TaskResponseDigest:           digest,
+			NonSignersPubkeysG1:          []*bls.G1Point{nonSigner.GetPubKeyG1()},
+			QuorumApksG1:                 []*bls.G1Point{bls.NewZeroG1Point().Add(signer.GetPubKeyG1()).Add(nonSigner.GetPubKeyG1())},
+			SignersApkG2:                 signer.GetPubKeyG2(),
+			SignersAggSigG1:              signer.SignMessage(digest),
+			NonSignerQuorumBitmapIndices: []uint32{1},
+			QuorumApkIndices:             []uint32{2},
+			TotalStakeIndices:            []uint32{3},
+			NonSignerStakeIndices:        [][]uint32{{4}},
+		},
+		Signers:    []types.OperatorId{types.OperatorIdFromKeyPair(signer)},
+		NonSigners: []types.OperatorId{types.OperatorIdFromKeyPair(nonSigner)},
+		CreatedAt:  createdAt,
+	}
+}
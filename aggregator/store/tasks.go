@@ -0,0 +1,45 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var ErrTaskNotFound = errors.New("task not found")
+
+type TaskStatus string
+
+const (
+	TaskStatusPending   TaskStatus = "pending"
+	TaskStatusCompleted TaskStatus = "completed"
+	TaskStatusFailed    TaskStatus = "failed"
+)
+
+// TaskRecord is the metadata kept for every task created by the aggregator
+type TaskRecord struct {
+	TaskIndex                  types.TaskIndex
+	Status                     TaskStatus
+	CreatedAt                  time.Time
+	ReferenceBlockNumber       uint32
+	QuorumNumbers              types.QuorumNums
+	QuorumThresholdPercentages types.QuorumThresholdPercentages
+	// DataHash is the keccak256 hash of the data sent to the operators
+	DataHash gethcommon.Hash
+	// Error is set when Status is TaskStatusFailed
+	Error string
+}
+
+// TaskRegistry allocates task indices and tracks the status of every task
+type TaskRegistry interface {
+	// CreateTask allocates the next task index, records the task as pending and
+	// returns the stored record. The TaskIndex and Status of task are ignored.
+	CreateTask(ctx context.Context, task TaskRecord) (*TaskRecord, error)
+	// UpdateTaskStatus sets the status of a task, errMsg is only kept for failed tasks
+	UpdateTaskStatus(ctx context.Context, taskIndex types.TaskIndex, status TaskStatus, errMsg string) error
+	// GetTask returns ErrTaskNotFound if the task was never created
+	GetTask(ctx context.Context, taskIndex types.TaskIndex) (*TaskRecord, error)
+}
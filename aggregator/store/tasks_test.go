@@ -0,0 +1,141 @@
+package store_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/testutils"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/Layr-Labs/teal/aggregator/store"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRegistries(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tasks.jsonl")
+	fileRegistry, err := store.NewFileTaskRegistry(testutils.GetTestLogger(), path)
+	require.NoError(t, err)
+	defer fileRegistry.Close()
+
+	registries := map[string]store.TaskRegistry{
+		"in memory": store.NewInMemoryTaskRegistry(),
+		"file":      fileRegistry,
+	}
+
+	for name, registry := range registries {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+
+			for i := 0; i < 3; i++ {
+				task, err := registry.CreateTask(ctx, newTestTaskRecord())
+				require.NoError(t, err)
+				assert.Equal(t, types.TaskIndex(i), task.TaskIndex)
+				assert.Equal(t, store.TaskStatusPending, task.Status)
+			}
+
+			require.NoError(t, registry.UpdateTaskStatus(ctx, 0, store.TaskStatusCompleted, ""))
+			require.NoError(t, registry.UpdateTaskStatus(ctx, 1, store.TaskStatusFailed, "task 1 expired"))
+			assert.ErrorIs(t, registry.UpdateTaskStatus(ctx, 42, store.TaskStatusFailed, ""), store.ErrTaskNotFound)
+
+			task, err := registry.GetTask(ctx, 1)
+			require.NoError(t, err)
+			assert.Equal(t, store.TaskStatusFailed, task.Status)
+			assert.Equal(t, "task 1 expired", task.Error)
+			assert.Equal(t, types.QuorumNums{0, 1}, task.QuorumNumbers)
+
+			_, err = registry.GetTask(ctx, 42)
+			assert.ErrorIs(t, err, store.ErrTaskNotFound)
+		})
+	}
+
+	t.Run("file registry resumes after reopening", func(t *testing.T) {
+		ctx := context.Background()
+		require.NoError(t, fileRegistry.Close())
+
+		reopened, err := store.NewFileTaskRegistry(testutils.GetTestLogger(), path)
+		require.NoError(t, err)
+		defer reopened.Close()
+
+		task, err := reopened.CreateTask(ctx, newTestTaskRecord())
+		require.NoError(t, err)
+		assert.Equal(t, types.TaskIndex(3), task.TaskIndex)
+
+		completed, err := reopened.GetTask(ctx, 0)
+		require.NoError(t, err)
+		assert.Equal(t, store.TaskStatusCompleted, completed.Status)
+
+		// task 2 was still pending when the registry was closed
+		interrupted, err := reopened.GetTask(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, store.TaskStatusFailed, interrupted.Status)
+		assert.Equal(t, crypto.Keccak256Hash([]byte("data")), interrupted.DataHash)
+	})
+}
+
+func TestFileTaskRegistryRecovery(t *testing.T) {
+	ctx := context.Background()
+	logger := testutils.GetTestLogger()
+
+	// journal returns the path of a journal holding two tasks followed by tail
+	journal := func(t *testing.T, tail string) string {
+		path := filepath.Join(t.TempDir(), "tasks.jsonl")
+		registry, err := store.NewFileTaskRegistry(logger, path)
+		require.NoError(t, err)
+		for range 2 {
+			task, err := registry.CreateTask(ctx, newTestTaskRecord())
+			require.NoError(t, err)
+			require.NoError(t, registry.UpdateTaskStatus(ctx, task.TaskIndex, store.TaskStatusCompleted, ""))
+		}
+		require.NoError(t, registry.Close())
+
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+		require.NoError(t, err)
+		_, err = file.WriteString(tail)
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		return path
+	}
+
+	t.Run("partially written last record is dropped", func(t *testing.T) {
+		path := journal(t, `{"task_index":2,"status":`)
+
+		registry, err := store.NewFileTaskRegistry(logger, path)
+		require.NoError(t, err)
+		task, err := registry.CreateTask(ctx, newTestTaskRecord())
+		require.NoError(t, err)
+		assert.Equal(t, types.TaskIndex(2), task.TaskIndex)
+		require.NoError(t, registry.Close())
+
+		// the journal is readable again after records were appended to it
+		reopened, err := store.NewFileTaskRegistry(logger, path)
+		require.NoError(t, err)
+		defer reopened.Close()
+		completed, err := reopened.GetTask(ctx, 1)
+		require.NoError(t, err)
+		assert.Equal(t, store.TaskStatusCompleted, completed.Status)
+		interrupted, err := reopened.GetTask(ctx, 2)
+		require.NoError(t, err)
+		assert.Equal(t, store.TaskStatusFailed, interrupted.Status)
+	})
+
+	t.Run("corrupted record before the last one is refused", func(t *testing.T) {
+		path := journal(t, "{\"task_index\":2,\n{}\n")
+
+		_, err := store.NewFileTaskRegistry(logger, path)
+		assert.Error(t, err)
+	})
+}
+
+func newTestTaskRecord() store.TaskRecord {
+	return store.TaskRecord{
+		CreatedAt:                  time.Now(),
+		ReferenceBlockNumber:       10,
+		QuorumNumbers:              types.QuorumNums{0, 1},
+		QuorumThresholdPercentages: types.QuorumThresholdPercentages{50, 60},
+		DataHash:                   crypto.Keccak256Hash([]byte("data")),
+	}
+}
@@ -0,0 +1,90 @@
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/Layr-Labs/teal/aggregator/store"
+)
+
+// TaskRequest describes a task whose index is allocated by the aggregator
+type TaskRequest struct {
+	ReferenceBlockNumber       uint32
+	QuorumNumbers              types.QuorumNums
+	QuorumThresholdPercentages types.QuorumThresholdPercentages
+	Data                       []byte
+	TimeToExpiry               time.Duration
+	// Policy overrides the default task policy of the service if set
+	Policy *TaskPolicy
+	// OperatorTimeout overrides the operator timeout of the service if set, see WithOperatorTimeout
+	OperatorTimeout time.Duration
+}
+
+// Task is a task that was created in the task registry and can be certified with CertifyTask
+type Task struct {
+	TaskRequest
+	TaskIndex types.TaskIndex
+}
+
+// TaskRegistry returns the registry task indices are allocated from
+func (s *AggregatorService) TaskRegistry() store.TaskRegistry {
+	return s.taskRegistry
+}
+
+// CreateTask allocates a task index for req and records the task as pending
+func (s *AggregatorService) CreateTask(ctx context.Context, req TaskRequest) (*Task, error) {
+	if err := ValidateQuorums(req.QuorumNumbers, req.QuorumThresholdPercentages); err != nil {
+		return nil, err
+	}
+	policy := s.defaultPolicy
+	if req.Policy != nil {
+		if err := req.Policy.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid task policy: %w", err)
+		}
+		policy = *req.Policy
+	}
+	if err := policy.ValidateExpiry(req.TimeToExpiry); err != nil {
+		return nil, fmt.Errorf("invalid task policy: %w", err)
+	}
+	if err := ValidateOperatorTimeout(req.OperatorTimeout, req.TimeToExpiry); err != nil {
+		return nil, err
+	}
+
+	record, err := s.taskRegistry.CreateTask(ctx, store.TaskRecord{
+		CreatedAt:                  time.Now(),
+		ReferenceBlockNumber:       req.ReferenceBlockNumber,
+		QuorumNumbers:              req.QuorumNumbers,
+		QuorumThresholdPercentages: req.QuorumThresholdPercentages,
+		DataHash:                   crypto.Keccak256Hash(req.Data),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create task: %w", err)
+	}
+
+	return &Task{
+		TaskRequest: req,
+		TaskIndex:   record.TaskIndex,
+	}, nil
+}
+
+// CertifyTask collects the certificate for a task created with CreateTask and
+// records whether it completed or failed in the task registry. Like GetMultiQuorumCertificate,
+// it returns the outcome of every operator along with the error if the task failed.
+func (s *AggregatorService) CertifyTask(ctx context.Context, task *Task) (*TaskResult, error) {
+	resp, err := s.certify(ctx, task)
+
+	status, errMsg := store.TaskStatusCompleted, ""
+	if err != nil {
+		status, errMsg = store.TaskStatusFailed, err.Error()
+	}
+	// use a fresh context so that the outcome is recorded even if ctx was cancelled
+	if updateErr := s.taskRegistry.UpdateTaskStatus(context.Background(), task.TaskIndex, status, errMsg); updateErr != nil {
+		s.logger.Error("Failed to update task status", "taskIndex", task.TaskIndex, "error", updateErr)
+	}
+
+	return resp, err
+}
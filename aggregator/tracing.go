@@ -0,0 +1,40 @@
+package aggregator
+
+import (
+	"fmt"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the name of the tracer the aggregator records its spans with
+const TracerName = "github.com/Layr-Labs/teal/aggregator"
+
+// taskAttributes describe task on its spans
+func taskAttributes(task *Task) trace.SpanStartOption {
+	quorumNumbers := make([]int, len(task.QuorumNumbers))
+	for i, quorumNumber := range task.QuorumNumbers {
+		quorumNumbers[i] = int(quorumNumber)
+	}
+	return trace.WithAttributes(
+		attribute.Int64("teal.task_index", int64(task.TaskIndex)),
+		attribute.Int64("teal.reference_block_number", int64(task.ReferenceBlockNumber)),
+		attribute.IntSlice("teal.quorum_numbers", quorumNumbers),
+	)
+}
+
+// operatorAttribute identifies an operator on its spans
+func operatorAttribute(operatorId types.OperatorId) attribute.KeyValue {
+	return attribute.String("teal.operator_id", fmt.Sprintf("%x", operatorId))
+}
+
+// endSpan ends span, recording err as its status if it is not nil
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,1983 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: aggregator.proto
+
+package v1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TaskStatus int32
+
+const (
+	TaskStatus_TASK_STATUS_UNSPECIFIED TaskStatus = 0
+	TaskStatus_TASK_STATUS_PENDING     TaskStatus = 1
+	TaskStatus_TASK_STATUS_COMPLETED   TaskStatus = 2
+	TaskStatus_TASK_STATUS_FAILED      TaskStatus = 3
+)
+
+// Enum value maps for TaskStatus.
+var (
+	TaskStatus_name = map[int32]string{
+		0: "TASK_STATUS_UNSPECIFIED",
+		1: "TASK_STATUS_PENDING",
+		2: "TASK_STATUS_COMPLETED",
+		3: "TASK_STATUS_FAILED",
+	}
+	TaskStatus_value = map[string]int32{
+		"TASK_STATUS_UNSPECIFIED": 0,
+		"TASK_STATUS_PENDING":     1,
+		"TASK_STATUS_COMPLETED":   2,
+		"TASK_STATUS_FAILED":      3,
+	}
+)
+
+func (x TaskStatus) Enum() *TaskStatus {
+	p := new(TaskStatus)
+	*p = x
+	return p
+}
+
+func (x TaskStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TaskStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_aggregator_proto_enumTypes[0].Descriptor()
+}
+
+func (TaskStatus) Type() protoreflect.EnumType {
+	return &file_aggregator_proto_enumTypes[0]
+}
+
+func (x TaskStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TaskStatus.Descriptor instead.
+func (TaskStatus) EnumDescriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{0}
+}
+
+type AggregationMode int32
+
+const (
+	// use the default policy of the aggregator
+	AggregationMode_AGGREGATION_MODE_UNSPECIFIED AggregationMode = 0
+	// keep collecting signatures for the window after the thresholds are met
+	AggregationMode_AGGREGATION_MODE_WAIT_FOR_WINDOW AggregationMode = 1
+	// return as soon as the thresholds are met
+	AggregationMode_AGGREGATION_MODE_RETURN_AT_THRESHOLD AggregationMode = 2
+	// wait until every operator responded or failed, aggregating at the latest a window before expiry
+	AggregationMode_AGGREGATION_MODE_WAIT_FOR_ALL_RESPONSIVE AggregationMode = 3
+)
+
+// Enum value maps for AggregationMode.
+var (
+	AggregationMode_name = map[int32]string{
+		0: "AGGREGATION_MODE_UNSPECIFIED",
+		1: "AGGREGATION_MODE_WAIT_FOR_WINDOW",
+		2: "AGGREGATION_MODE_RETURN_AT_THRESHOLD",
+		3: "AGGREGATION_MODE_WAIT_FOR_ALL_RESPONSIVE",
+	}
+	AggregationMode_value = map[string]int32{
+		"AGGREGATION_MODE_UNSPECIFIED":             0,
+		"AGGREGATION_MODE_WAIT_FOR_WINDOW":         1,
+		"AGGREGATION_MODE_RETURN_AT_THRESHOLD":     2,
+		"AGGREGATION_MODE_WAIT_FOR_ALL_RESPONSIVE": 3,
+	}
+)
+
+func (x AggregationMode) Enum() *AggregationMode {
+	p := new(AggregationMode)
+	*p = x
+	return p
+}
+
+func (x AggregationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AggregationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_aggregator_proto_enumTypes[1].Descriptor()
+}
+
+func (AggregationMode) Type() protoreflect.EnumType {
+	return &file_aggregator_proto_enumTypes[1]
+}
+
+func (x AggregationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AggregationMode.Descriptor instead.
+func (AggregationMode) EnumDescriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{1}
+}
+
+type OutcomeClass int32
+
+const (
+	OutcomeClass_OUTCOME_CLASS_UNSPECIFIED OutcomeClass = 0
+	// the operator had not answered when the task completed
+	OutcomeClass_OUTCOME_CLASS_PENDING OutcomeClass = 1
+	// the operator's signature was verified and aggregated
+	OutcomeClass_OUTCOME_CLASS_SIGNED OutcomeClass = 2
+	// the operator signed a different response than the one certified
+	OutcomeClass_OUTCOME_CLASS_DIVERGENT_RESPONSE OutcomeClass = 3
+	OutcomeClass_OUTCOME_CLASS_TIMEOUT            OutcomeClass = 4
+	OutcomeClass_OUTCOME_CLASS_CANCELED           OutcomeClass = 5
+	OutcomeClass_OUTCOME_CLASS_UNREACHABLE        OutcomeClass = 6
+	// the operator answered with a gRPC error
+	OutcomeClass_OUTCOME_CLASS_RPC_ERROR OutcomeClass = 7
+	// the operator's signature could not be decoded
+	OutcomeClass_OUTCOME_CLASS_MALFORMED_SIGNATURE OutcomeClass = 8
+	// the operator's signature did not verify
+	OutcomeClass_OUTCOME_CLASS_REJECTED_SIGNATURE OutcomeClass = 9
+	// the operator was left out of the first wave of the aggregator's selection strategy
+	OutcomeClass_OUTCOME_CLASS_NOT_CONTACTED OutcomeClass = 10
+)
+
+// Enum value maps for OutcomeClass.
+var (
+	OutcomeClass_name = map[int32]string{
+		0:  "OUTCOME_CLASS_UNSPECIFIED",
+		1:  "OUTCOME_CLASS_PENDING",
+		2:  "OUTCOME_CLASS_SIGNED",
+		3:  "OUTCOME_CLASS_DIVERGENT_RESPONSE",
+		4:  "OUTCOME_CLASS_TIMEOUT",
+		5:  "OUTCOME_CLASS_CANCELED",
+		6:  "OUTCOME_CLASS_UNREACHABLE",
+		7:  "OUTCOME_CLASS_RPC_ERROR",
+		8:  "OUTCOME_CLASS_MALFORMED_SIGNATURE",
+		9:  "OUTCOME_CLASS_REJECTED_SIGNATURE",
+		10: "OUTCOME_CLASS_NOT_CONTACTED",
+	}
+	OutcomeClass_value = map[string]int32{
+		"OUTCOME_CLASS_UNSPECIFIED":         0,
+		"OUTCOME_CLASS_PENDING":             1,
+		"OUTCOME_CLASS_SIGNED":              2,
+		"OUTCOME_CLASS_DIVERGENT_RESPONSE":  3,
+		"OUTCOME_CLASS_TIMEOUT":             4,
+		"OUTCOME_CLASS_CANCELED":            5,
+		"OUTCOME_CLASS_UNREACHABLE":         6,
+		"OUTCOME_CLASS_RPC_ERROR":           7,
+		"OUTCOME_CLASS_MALFORMED_SIGNATURE": 8,
+		"OUTCOME_CLASS_REJECTED_SIGNATURE":  9,
+		"OUTCOME_CLASS_NOT_CONTACTED":       10,
+	}
+)
+
+func (x OutcomeClass) Enum() *OutcomeClass {
+	p := new(OutcomeClass)
+	*p = x
+	return p
+}
+
+func (x OutcomeClass) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OutcomeClass) Descriptor() protoreflect.EnumDescriptor {
+	return file_aggregator_proto_enumTypes[2].Descriptor()
+}
+
+func (OutcomeClass) Type() protoreflect.EnumType {
+	return &file_aggregator_proto_enumTypes[2]
+}
+
+func (x OutcomeClass) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OutcomeClass.Descriptor instead.
+func (OutcomeClass) EnumDescriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{2}
+}
+
+type TaskPolicy struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mode     AggregationMode `protobuf:"varint,1,opt,name=mode,proto3,enum=aggregator.v1.AggregationMode" json:"mode,omitempty"`
+	WindowMs uint64          `protobuf:"varint,2,opt,name=window_ms,json=windowMs,proto3" json:"window_ms,omitempty"`
+}
+
+func (x *TaskPolicy) Reset() {
+	*x = TaskPolicy{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TaskPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TaskPolicy) ProtoMessage() {}
+
+func (x *TaskPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TaskPolicy.ProtoReflect.Descriptor instead.
+func (*TaskPolicy) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TaskPolicy) GetMode() AggregationMode {
+	if x != nil {
+		return x.Mode
+	}
+	return AggregationMode_AGGREGATION_MODE_UNSPECIFIED
+}
+
+func (x *TaskPolicy) GetWindowMs() uint64 {
+	if x != nil {
+		return x.WindowMs
+	}
+	return 0
+}
+
+type SubmitTaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReferenceBlockNumber uint32 `protobuf:"varint,2,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	// quorum numbers in ascending order
+	QuorumNumbers []byte `protobuf:"bytes,3,opt,name=quorum_numbers,json=quorumNumbers,proto3" json:"quorum_numbers,omitempty"`
+	// threshold percentage for each of the quorum numbers
+	QuorumThresholdPercentages []byte `protobuf:"bytes,4,opt,name=quorum_threshold_percentages,json=quorumThresholdPercentages,proto3" json:"quorum_threshold_percentages,omitempty"`
+	Data                       []byte `protobuf:"bytes,5,opt,name=data,proto3" json:"data,omitempty"`
+	TimeToExpiryMs             uint64 `protobuf:"varint,6,opt,name=time_to_expiry_ms,json=timeToExpiryMs,proto3" json:"time_to_expiry_ms,omitempty"`
+	// overrides the default policy of the aggregator if set
+	Policy *TaskPolicy `protobuf:"bytes,7,opt,name=policy,proto3" json:"policy,omitempty"`
+	// overrides the default operator timeout of the aggregator if set, must be shorter than time_to_expiry_ms
+	OperatorTimeoutMs uint64 `protobuf:"varint,8,opt,name=operator_timeout_ms,json=operatorTimeoutMs,proto3" json:"operator_timeout_ms,omitempty"`
+}
+
+func (x *SubmitTaskRequest) Reset() {
+	*x = SubmitTaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTaskRequest) ProtoMessage() {}
+
+func (x *SubmitTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTaskRequest.ProtoReflect.Descriptor instead.
+func (*SubmitTaskRequest) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubmitTaskRequest) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *SubmitTaskRequest) GetQuorumNumbers() []byte {
+	if x != nil {
+		return x.QuorumNumbers
+	}
+	return nil
+}
+
+func (x *SubmitTaskRequest) GetQuorumThresholdPercentages() []byte {
+	if x != nil {
+		return x.QuorumThresholdPercentages
+	}
+	return nil
+}
+
+func (x *SubmitTaskRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *SubmitTaskRequest) GetTimeToExpiryMs() uint64 {
+	if x != nil {
+		return x.TimeToExpiryMs
+	}
+	return 0
+}
+
+func (x *SubmitTaskRequest) GetPolicy() *TaskPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+func (x *SubmitTaskRequest) GetOperatorTimeoutMs() uint64 {
+	if x != nil {
+		return x.OperatorTimeoutMs
+	}
+	return 0
+}
+
+type SubmitTaskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex uint32 `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+}
+
+func (x *SubmitTaskResponse) Reset() {
+	*x = SubmitTaskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitTaskResponse) ProtoMessage() {}
+
+func (x *SubmitTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitTaskResponse.ProtoReflect.Descriptor instead.
+func (*SubmitTaskResponse) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubmitTaskResponse) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex uint32 `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTaskRequest) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+type GetTaskResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex                  uint32                 `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+	Status                     TaskStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=aggregator.v1.TaskStatus" json:"status,omitempty"`
+	CreatedAt                  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ReferenceBlockNumber       uint32                 `protobuf:"varint,4,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	QuorumNumbers              []byte                 `protobuf:"bytes,5,opt,name=quorum_numbers,json=quorumNumbers,proto3" json:"quorum_numbers,omitempty"`
+	QuorumThresholdPercentages []byte                 `protobuf:"bytes,6,opt,name=quorum_threshold_percentages,json=quorumThresholdPercentages,proto3" json:"quorum_threshold_percentages,omitempty"`
+	// keccak256 hash of the task data
+	DataHash []byte `protobuf:"bytes,7,opt,name=data_hash,json=dataHash,proto3" json:"data_hash,omitempty"`
+	// set when status is TASK_STATUS_FAILED
+	Error string `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *GetTaskResponse) Reset() {
+	*x = GetTaskResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskResponse) ProtoMessage() {}
+
+func (x *GetTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskResponse.ProtoReflect.Descriptor instead.
+func (*GetTaskResponse) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetTaskResponse) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+func (x *GetTaskResponse) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *GetTaskResponse) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *GetTaskResponse) GetQuorumNumbers() []byte {
+	if x != nil {
+		return x.QuorumNumbers
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetQuorumThresholdPercentages() []byte {
+	if x != nil {
+		return x.QuorumThresholdPercentages
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetDataHash() []byte {
+	if x != nil {
+		return x.DataHash
+	}
+	return nil
+}
+
+func (x *GetTaskResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type GetCertificateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex uint32 `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+}
+
+func (x *GetCertificateRequest) Reset() {
+	*x = GetCertificateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCertificateRequest) ProtoMessage() {}
+
+func (x *GetCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCertificateRequest.ProtoReflect.Descriptor instead.
+func (*GetCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetCertificateRequest) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+type GetCertificateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status TaskStatus `protobuf:"varint,1,opt,name=status,proto3,enum=aggregator.v1.TaskStatus" json:"status,omitempty"`
+	// set when status is TASK_STATUS_COMPLETED
+	Certificate *Certificate `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// set when status is TASK_STATUS_FAILED
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// how each operator took part in the task, only available for tasks submitted
+	// since the aggregator last started
+	Operators []*OperatorOutcome `protobuf:"bytes,4,rep,name=operators,proto3" json:"operators,omitempty"`
+}
+
+func (x *GetCertificateResponse) Reset() {
+	*x = GetCertificateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCertificateResponse) ProtoMessage() {}
+
+func (x *GetCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCertificateResponse.ProtoReflect.Descriptor instead.
+func (*GetCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetCertificateResponse) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *GetCertificateResponse) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *GetCertificateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetCertificateResponse) GetOperators() []*OperatorOutcome {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+type WaitForCertificateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex uint32 `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+}
+
+func (x *WaitForCertificateRequest) Reset() {
+	*x = WaitForCertificateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForCertificateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForCertificateRequest) ProtoMessage() {}
+
+func (x *WaitForCertificateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForCertificateRequest.ProtoReflect.Descriptor instead.
+func (*WaitForCertificateRequest) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WaitForCertificateRequest) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+type WaitForCertificateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status TaskStatus `protobuf:"varint,1,opt,name=status,proto3,enum=aggregator.v1.TaskStatus" json:"status,omitempty"`
+	// set when status is TASK_STATUS_COMPLETED
+	Certificate *Certificate `protobuf:"bytes,2,opt,name=certificate,proto3" json:"certificate,omitempty"`
+	// set when status is TASK_STATUS_FAILED
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	// how each operator took part in the task, only available for tasks submitted
+	// since the aggregator last started
+	Operators []*OperatorOutcome `protobuf:"bytes,4,rep,name=operators,proto3" json:"operators,omitempty"`
+}
+
+func (x *WaitForCertificateResponse) Reset() {
+	*x = WaitForCertificateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WaitForCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WaitForCertificateResponse) ProtoMessage() {}
+
+func (x *WaitForCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WaitForCertificateResponse.ProtoReflect.Descriptor instead.
+func (*WaitForCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WaitForCertificateResponse) GetStatus() TaskStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TaskStatus_TASK_STATUS_UNSPECIFIED
+}
+
+func (x *WaitForCertificateResponse) GetCertificate() *Certificate {
+	if x != nil {
+		return x.Certificate
+	}
+	return nil
+}
+
+func (x *WaitForCertificateResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *WaitForCertificateResponse) GetOperators() []*OperatorOutcome {
+	if x != nil {
+		return x.Operators
+	}
+	return nil
+}
+
+type OperatorOutcome struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OperatorId []byte       `protobuf:"bytes,1,opt,name=operator_id,json=operatorId,proto3" json:"operator_id,omitempty"`
+	Socket     string       `protobuf:"bytes,2,opt,name=socket,proto3" json:"socket,omitempty"`
+	Class      OutcomeClass `protobuf:"varint,3,opt,name=class,proto3,enum=aggregator.v1.OutcomeClass" json:"class,omitempty"`
+	// time the operator took to answer, 0 if it is still pending
+	LatencyMs uint64 `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"`
+	// set if the request or signature failed
+	Error string `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	// digest of the response the operator signed, empty if it didn't answer
+	ResponseDigest []byte `protobuf:"bytes,6,opt,name=response_digest,json=responseDigest,proto3" json:"response_digest,omitempty"`
+}
+
+func (x *OperatorOutcome) Reset() {
+	*x = OperatorOutcome{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OperatorOutcome) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OperatorOutcome) ProtoMessage() {}
+
+func (x *OperatorOutcome) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OperatorOutcome.ProtoReflect.Descriptor instead.
+func (*OperatorOutcome) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *OperatorOutcome) GetOperatorId() []byte {
+	if x != nil {
+		return x.OperatorId
+	}
+	return nil
+}
+
+func (x *OperatorOutcome) GetSocket() string {
+	if x != nil {
+		return x.Socket
+	}
+	return ""
+}
+
+func (x *OperatorOutcome) GetClass() OutcomeClass {
+	if x != nil {
+		return x.Class
+	}
+	return OutcomeClass_OUTCOME_CLASS_UNSPECIFIED
+}
+
+func (x *OperatorOutcome) GetLatencyMs() uint64 {
+	if x != nil {
+		return x.LatencyMs
+	}
+	return 0
+}
+
+func (x *OperatorOutcome) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *OperatorOutcome) GetResponseDigest() []byte {
+	if x != nil {
+		return x.ResponseDigest
+	}
+	return nil
+}
+
+type TimeRange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// inclusive
+	From *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`
+	// exclusive
+	To *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`
+}
+
+func (x *TimeRange) Reset() {
+	*x = TimeRange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TimeRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeRange) ProtoMessage() {}
+
+func (x *TimeRange) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeRange.ProtoReflect.Descriptor instead.
+func (*TimeRange) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *TimeRange) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TimeRange) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+type ListCertificatesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Filter:
+	//	*ListCertificatesRequest_TaskResponseDigest
+	//	*ListCertificatesRequest_CreatedAt
+	Filter isListCertificatesRequest_Filter `protobuf_oneof:"filter"`
+}
+
+func (x *ListCertificatesRequest) Reset() {
+	*x = ListCertificatesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCertificatesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificatesRequest) ProtoMessage() {}
+
+func (x *ListCertificatesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificatesRequest.ProtoReflect.Descriptor instead.
+func (*ListCertificatesRequest) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{11}
+}
+
+func (m *ListCertificatesRequest) GetFilter() isListCertificatesRequest_Filter {
+	if m != nil {
+		return m.Filter
+	}
+	return nil
+}
+
+func (x *ListCertificatesRequest) GetTaskResponseDigest() []byte {
+	if x, ok := x.GetFilter().(*ListCertificatesRequest_TaskResponseDigest); ok {
+		return x.TaskResponseDigest
+	}
+	return nil
+}
+
+func (x *ListCertificatesRequest) GetCreatedAt() *TimeRange {
+	if x, ok := x.GetFilter().(*ListCertificatesRequest_CreatedAt); ok {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type isListCertificatesRequest_Filter interface {
+	isListCertificatesRequest_Filter()
+}
+
+type ListCertificatesRequest_TaskResponseDigest struct {
+	TaskResponseDigest []byte `protobuf:"bytes,1,opt,name=task_response_digest,json=taskResponseDigest,proto3,oneof"`
+}
+
+type ListCertificatesRequest_CreatedAt struct {
+	CreatedAt *TimeRange `protobuf:"bytes,2,opt,name=created_at,json=createdAt,proto3,oneof"`
+}
+
+func (*ListCertificatesRequest_TaskResponseDigest) isListCertificatesRequest_Filter() {}
+
+func (*ListCertificatesRequest_CreatedAt) isListCertificatesRequest_Filter() {}
+
+type ListCertificatesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Certificates []*Certificate `protobuf:"bytes,1,rep,name=certificates,proto3" json:"certificates,omitempty"`
+}
+
+func (x *ListCertificatesResponse) Reset() {
+	*x = ListCertificatesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListCertificatesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCertificatesResponse) ProtoMessage() {}
+
+func (x *ListCertificatesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCertificatesResponse.ProtoReflect.Descriptor instead.
+func (*ListCertificatesResponse) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListCertificatesResponse) GetCertificates() []*Certificate {
+	if x != nil {
+		return x.Certificates
+	}
+	return nil
+}
+
+// G1Point coordinates are 32 byte big endian integers
+type G1Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	X []byte `protobuf:"bytes,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y []byte `protobuf:"bytes,2,opt,name=y,proto3" json:"y,omitempty"`
+}
+
+func (x *G1Point) Reset() {
+	*x = G1Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *G1Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*G1Point) ProtoMessage() {}
+
+func (x *G1Point) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use G1Point.ProtoReflect.Descriptor instead.
+func (*G1Point) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *G1Point) GetX() []byte {
+	if x != nil {
+		return x.X
+	}
+	return nil
+}
+
+func (x *G1Point) GetY() []byte {
+	if x != nil {
+		return x.Y
+	}
+	return nil
+}
+
+// G2Point coordinates are 32 byte big endian integers
+type G2Point struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	XA0 []byte `protobuf:"bytes,1,opt,name=x_a0,json=xA0,proto3" json:"x_a0,omitempty"`
+	XA1 []byte `protobuf:"bytes,2,opt,name=x_a1,json=xA1,proto3" json:"x_a1,omitempty"`
+	YA0 []byte `protobuf:"bytes,3,opt,name=y_a0,json=yA0,proto3" json:"y_a0,omitempty"`
+	YA1 []byte `protobuf:"bytes,4,opt,name=y_a1,json=yA1,proto3" json:"y_a1,omitempty"`
+}
+
+func (x *G2Point) Reset() {
+	*x = G2Point{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *G2Point) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*G2Point) ProtoMessage() {}
+
+func (x *G2Point) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use G2Point.ProtoReflect.Descriptor instead.
+func (*G2Point) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *G2Point) GetXA0() []byte {
+	if x != nil {
+		return x.XA0
+	}
+	return nil
+}
+
+func (x *G2Point) GetXA1() []byte {
+	if x != nil {
+		return x.XA1
+	}
+	return nil
+}
+
+func (x *G2Point) GetYA0() []byte {
+	if x != nil {
+		return x.YA0
+	}
+	return nil
+}
+
+func (x *G2Point) GetYA1() []byte {
+	if x != nil {
+		return x.YA1
+	}
+	return nil
+}
+
+type NonSignerStakeIndices struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Indices []uint32 `protobuf:"varint,1,rep,packed,name=indices,proto3" json:"indices,omitempty"`
+}
+
+func (x *NonSignerStakeIndices) Reset() {
+	*x = NonSignerStakeIndices{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NonSignerStakeIndices) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NonSignerStakeIndices) ProtoMessage() {}
+
+func (x *NonSignerStakeIndices) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NonSignerStakeIndices.ProtoReflect.Descriptor instead.
+func (*NonSignerStakeIndices) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NonSignerStakeIndices) GetIndices() []uint32 {
+	if x != nil {
+		return x.Indices
+	}
+	return nil
+}
+
+// Certificate contains everything needed to call BLSSignatureChecker.checkSignatures on-chain
+type Certificate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TaskIndex                    uint32                   `protobuf:"varint,1,opt,name=task_index,json=taskIndex,proto3" json:"task_index,omitempty"`
+	ReferenceBlockNumber         uint32                   `protobuf:"varint,2,opt,name=reference_block_number,json=referenceBlockNumber,proto3" json:"reference_block_number,omitempty"`
+	QuorumNumbers                []byte                   `protobuf:"bytes,3,opt,name=quorum_numbers,json=quorumNumbers,proto3" json:"quorum_numbers,omitempty"`
+	TaskResponse                 []byte                   `protobuf:"bytes,4,opt,name=task_response,json=taskResponse,proto3" json:"task_response,omitempty"`
+	TaskResponseDigest           []byte                   `protobuf:"bytes,5,opt,name=task_response_digest,json=taskResponseDigest,proto3" json:"task_response_digest,omitempty"`
+	NonSignersPubkeysG1          []*G1Point               `protobuf:"bytes,6,rep,name=non_signers_pubkeys_g1,json=nonSignersPubkeysG1,proto3" json:"non_signers_pubkeys_g1,omitempty"`
+	QuorumApksG1                 []*G1Point               `protobuf:"bytes,7,rep,name=quorum_apks_g1,json=quorumApksG1,proto3" json:"quorum_apks_g1,omitempty"`
+	SignersApkG2                 *G2Point                 `protobuf:"bytes,8,opt,name=signers_apk_g2,json=signersApkG2,proto3" json:"signers_apk_g2,omitempty"`
+	SignersAggSigG1              *G1Point                 `protobuf:"bytes,9,opt,name=signers_agg_sig_g1,json=signersAggSigG1,proto3" json:"signers_agg_sig_g1,omitempty"`
+	NonSignerQuorumBitmapIndices []uint32                 `protobuf:"varint,10,rep,packed,name=non_signer_quorum_bitmap_indices,json=nonSignerQuorumBitmapIndices,proto3" json:"non_signer_quorum_bitmap_indices,omitempty"`
+	QuorumApkIndices             []uint32                 `protobuf:"varint,11,rep,packed,name=quorum_apk_indices,json=quorumApkIndices,proto3" json:"quorum_apk_indices,omitempty"`
+	TotalStakeIndices            []uint32                 `protobuf:"varint,12,rep,packed,name=total_stake_indices,json=totalStakeIndices,proto3" json:"total_stake_indices,omitempty"`
+	NonSignerStakeIndices        []*NonSignerStakeIndices `protobuf:"bytes,13,rep,name=non_signer_stake_indices,json=nonSignerStakeIndices,proto3" json:"non_signer_stake_indices,omitempty"`
+}
+
+func (x *Certificate) Reset() {
+	*x = Certificate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_aggregator_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Certificate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Certificate) ProtoMessage() {}
+
+func (x *Certificate) ProtoReflect() protoreflect.Message {
+	mi := &file_aggregator_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Certificate.ProtoReflect.Descriptor instead.
+func (*Certificate) Descriptor() ([]byte, []int) {
+	return file_aggregator_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Certificate) GetTaskIndex() uint32 {
+	if x != nil {
+		return x.TaskIndex
+	}
+	return 0
+}
+
+func (x *Certificate) GetReferenceBlockNumber() uint32 {
+	if x != nil {
+		return x.ReferenceBlockNumber
+	}
+	return 0
+}
+
+func (x *Certificate) GetQuorumNumbers() []byte {
+	if x != nil {
+		return x.QuorumNumbers
+	}
+	return nil
+}
+
+func (x *Certificate) GetTaskResponse() []byte {
+	if x != nil {
+		return x.TaskResponse
+	}
+	return nil
+}
+
+func (x *Certificate) GetTaskResponseDigest() []byte {
+	if x != nil {
+		return x.TaskResponseDigest
+	}
+	return nil
+}
+
+func (x *Certificate) GetNonSignersPubkeysG1() []*G1Point {
+	if x != nil {
+		return x.NonSignersPubkeysG1
+	}
+	return nil
+}
+
+func (x *Certificate) GetQuorumApksG1() []*G1Point {
+	if x != nil {
+		return x.QuorumApksG1
+	}
+	return nil
+}
+
+func (x *Certificate) GetSignersApkG2() *G2Point {
+	if x != nil {
+		return x.SignersApkG2
+	}
+	return nil
+}
+
+func (x *Certificate) GetSignersAggSigG1() *G1Point {
+	if x != nil {
+		return x.SignersAggSigG1
+	}
+	return nil
+}
+
+func (x *Certificate) GetNonSignerQuorumBitmapIndices() []uint32 {
+	if x != nil {
+		return x.NonSignerQuorumBitmapIndices
+	}
+	return nil
+}
+
+func (x *Certificate) GetQuorumApkIndices() []uint32 {
+	if x != nil {
+		return x.QuorumApkIndices
+	}
+	return nil
+}
+
+func (x *Certificate) GetTotalStakeIndices() []uint32 {
+	if x != nil {
+		return x.TotalStakeIndices
+	}
+	return nil
+}
+
+func (x *Certificate) GetNonSignerStakeIndices() []*NonSignerStakeIndices {
+	if x != nil {
+		return x.NonSignerStakeIndices
+	}
+	return nil
+}
+
+var File_aggregator_proto protoreflect.FileDescriptor
+
+var file_aggregator_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x0d, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0x5d, 0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x12, 0x32, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1e,
+	0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x04,
+	0x6d, 0x6f, 0x64, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x6d,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x4d,
+	0x73, 0x22, 0xda, 0x02, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25, 0x0a,
+	0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x12, 0x40, 0x0a, 0x1c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x61, 0x67, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x29, 0x0a, 0x11, 0x74, 0x69,
+	0x6d, 0x65, 0x5f, 0x74, 0x6f, 0x5f, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x5f, 0x6d, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x54, 0x6f, 0x45, 0x78, 0x70,
+	0x69, 0x72, 0x79, 0x4d, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74,
+	0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79,
+	0x52, 0x06, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x2e, 0x0a, 0x13, 0x6f, 0x70, 0x65, 0x72,
+	0x61, 0x74, 0x6f, 0x72, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x54,
+	0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x4d, 0x73, 0x4a, 0x04, 0x08, 0x01, 0x10, 0x02, 0x22, 0x33,
+	0x0a, 0x12, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0x2f, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x73, 0x6b, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x22, 0xf0, 0x02, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61,
+	0x73, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25, 0x0a, 0x0e, 0x71,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x73, 0x12, 0x40, 0x0a, 0x1c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x74, 0x68, 0x72,
+	0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67,
+	0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x1a, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x61, 0x67, 0x65, 0x73, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x61, 0x74, 0x61, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x64, 0x61, 0x74, 0x61, 0x48, 0x61, 0x73,
+	0x68, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x36, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22,
+	0xdd, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a,
+	0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x0b,
+	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x3c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22,
+	0x3a, 0x0a, 0x19, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66,
+	0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x61, 0x73, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d,
+	0x52, 0x09, 0x74, 0x61, 0x73, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xe1, 0x01, 0x0a, 0x1a,
+	0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x31, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x3c, 0x0a,
+	0x0b, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x0b,
+	0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x3c, 0x0a, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x75, 0x74,
+	0x63, 0x6f, 0x6d, 0x65, 0x52, 0x09, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x73, 0x22,
+	0xdb, 0x01, 0x0a, 0x0f, 0x4f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0a, 0x6f, 0x70, 0x65, 0x72, 0x61, 0x74,
+	0x6f, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x31, 0x0a, 0x05,
+	0x63, 0x6c, 0x61, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x63,
+	0x6f, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x52, 0x05, 0x63, 0x6c, 0x61, 0x73, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x09, 0x6c, 0x61, 0x74, 0x65, 0x6e, 0x63, 0x79, 0x4d, 0x73, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x12, 0x27, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0e, 0x72,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x22, 0x67, 0x0a,
+	0x09, 0x54, 0x69, 0x6d, 0x65, 0x52, 0x61, 0x6e, 0x67, 0x65, 0x12, 0x2e, 0x0a, 0x04, 0x66, 0x72,
+	0x6f, 0x6d, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x66, 0x72, 0x6f, 0x6d, 0x12, 0x2a, 0x0a, 0x02, 0x74, 0x6f,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x02, 0x74, 0x6f, 0x22, 0x92, 0x01, 0x0a, 0x17, 0x4c, 0x69, 0x73, 0x74, 0x43,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x32, 0x0a, 0x14, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c,
+	0x48, 0x00, 0x52, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x52,
+	0x61, 0x6e, 0x67, 0x65, 0x48, 0x00, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x42, 0x08, 0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22, 0x5a, 0x0a, 0x18, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65,
+	0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x0c, 0x63, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x22, 0x25, 0x0a, 0x07, 0x47, 0x31, 0x50, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x78,
+	0x12, 0x0c, 0x0a, 0x01, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x01, 0x79, 0x22, 0x55,
+	0x0a, 0x07, 0x47, 0x32, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x11, 0x0a, 0x04, 0x78, 0x5f, 0x61,
+	0x30, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x78, 0x41, 0x30, 0x12, 0x11, 0x0a, 0x04,
+	0x78, 0x5f, 0x61, 0x31, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x78, 0x41, 0x31, 0x12,
+	0x11, 0x0a, 0x04, 0x79, 0x5f, 0x61, 0x30, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x03, 0x79,
+	0x41, 0x30, 0x12, 0x11, 0x0a, 0x04, 0x79, 0x5f, 0x61, 0x31, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x03, 0x79, 0x41, 0x31, 0x22, 0x31, 0x0a, 0x15, 0x4e, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0d, 0x52,
+	0x07, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x22, 0xf3, 0x05, 0x0a, 0x0b, 0x43, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x74, 0x61, 0x73, 0x6b,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x74, 0x61,
+	0x73, 0x6b, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x34, 0x0a, 0x16, 0x72, 0x65, 0x66, 0x65, 0x72,
+	0x65, 0x6e, 0x63, 0x65, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65,
+	0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x14, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e,
+	0x63, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x25, 0x0a,
+	0x0e, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x4e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x74, 0x61, 0x73, 0x6b, 0x5f, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0c, 0x74, 0x61, 0x73,
+	0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x30, 0x0a, 0x14, 0x74, 0x61, 0x73,
+	0x6b, 0x5f, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x12, 0x74, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x4b, 0x0a, 0x16, 0x6e,
+	0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x5f, 0x70, 0x75, 0x62, 0x6b, 0x65,
+	0x79, 0x73, 0x5f, 0x67, 0x31, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x31, 0x50, 0x6f,
+	0x69, 0x6e, 0x74, 0x52, 0x13, 0x6e, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x50,
+	0x75, 0x62, 0x6b, 0x65, 0x79, 0x73, 0x47, 0x31, 0x12, 0x3c, 0x0a, 0x0e, 0x71, 0x75, 0x6f, 0x72,
+	0x75, 0x6d, 0x5f, 0x61, 0x70, 0x6b, 0x73, 0x5f, 0x67, 0x31, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x31, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d,
+	0x41, 0x70, 0x6b, 0x73, 0x47, 0x31, 0x12, 0x3c, 0x0a, 0x0e, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x73, 0x5f, 0x61, 0x70, 0x6b, 0x5f, 0x67, 0x32, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x32, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0c, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x41,
+	0x70, 0x6b, 0x47, 0x32, 0x12, 0x43, 0x0a, 0x12, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x73, 0x5f,
+	0x61, 0x67, 0x67, 0x5f, 0x73, 0x69, 0x67, 0x5f, 0x67, 0x31, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x31, 0x50, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x0f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72,
+	0x73, 0x41, 0x67, 0x67, 0x53, 0x69, 0x67, 0x47, 0x31, 0x12, 0x46, 0x0a, 0x20, 0x6e, 0x6f, 0x6e,
+	0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x62,
+	0x69, 0x74, 0x6d, 0x61, 0x70, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0a, 0x20,
+	0x03, 0x28, 0x0d, 0x52, 0x1c, 0x6e, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x51, 0x75,
+	0x6f, 0x72, 0x75, 0x6d, 0x42, 0x69, 0x74, 0x6d, 0x61, 0x70, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65,
+	0x73, 0x12, 0x2c, 0x0a, 0x12, 0x71, 0x75, 0x6f, 0x72, 0x75, 0x6d, 0x5f, 0x61, 0x70, 0x6b, 0x5f,
+	0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x10, 0x71,
+	0x75, 0x6f, 0x72, 0x75, 0x6d, 0x41, 0x70, 0x6b, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x2e, 0x0a, 0x13, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x6b, 0x65, 0x5f, 0x69,
+	0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x11, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x12,
+	0x5d, 0x0a, 0x18, 0x6e, 0x6f, 0x6e, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x5f, 0x73, 0x74,
+	0x61, 0x6b, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x24, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4e, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x53, 0x74, 0x61, 0x6b, 0x65,
+	0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x52, 0x15, 0x6e, 0x6f, 0x6e, 0x53, 0x69, 0x67, 0x6e,
+	0x65, 0x72, 0x53, 0x74, 0x61, 0x6b, 0x65, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x2a, 0x75,
+	0x0a, 0x0a, 0x54, 0x61, 0x73, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1b, 0x0a, 0x17,
+	0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x17, 0x0a, 0x13, 0x54, 0x41, 0x53,
+	0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47,
+	0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x16, 0x0a,
+	0x12, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x46, 0x41, 0x49,
+	0x4c, 0x45, 0x44, 0x10, 0x03, 0x2a, 0xb1, 0x01, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x47, 0x47,
+	0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e,
+	0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x24, 0x0a, 0x20, 0x41,
+	0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x57, 0x41, 0x49, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x5f, 0x57, 0x49, 0x4e, 0x44, 0x4f, 0x57, 0x10,
+	0x01, 0x12, 0x28, 0x0a, 0x24, 0x41, 0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x54, 0x55, 0x52, 0x4e, 0x5f, 0x41, 0x54, 0x5f,
+	0x54, 0x48, 0x52, 0x45, 0x53, 0x48, 0x4f, 0x4c, 0x44, 0x10, 0x02, 0x12, 0x2c, 0x0a, 0x28, 0x41,
+	0x47, 0x47, 0x52, 0x45, 0x47, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f,
+	0x57, 0x41, 0x49, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x5f, 0x41, 0x4c, 0x4c, 0x5f, 0x52, 0x45, 0x53,
+	0x50, 0x4f, 0x4e, 0x53, 0x49, 0x56, 0x45, 0x10, 0x03, 0x2a, 0xe9, 0x02, 0x0a, 0x0c, 0x4f, 0x75,
+	0x74, 0x63, 0x6f, 0x6d, 0x65, 0x43, 0x6c, 0x61, 0x73, 0x73, 0x12, 0x1d, 0x0a, 0x19, 0x4f, 0x55,
+	0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x55, 0x54,
+	0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49,
+	0x4e, 0x47, 0x10, 0x01, 0x12, 0x18, 0x0a, 0x14, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f,
+	0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x45, 0x44, 0x10, 0x02, 0x12, 0x24,
+	0x0a, 0x20, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f,
+	0x44, 0x49, 0x56, 0x45, 0x52, 0x47, 0x45, 0x4e, 0x54, 0x5f, 0x52, 0x45, 0x53, 0x50, 0x4f, 0x4e,
+	0x53, 0x45, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f,
+	0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x54, 0x49, 0x4d, 0x45, 0x4f, 0x55, 0x54, 0x10, 0x04, 0x12,
+	0x1a, 0x0a, 0x16, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53,
+	0x5f, 0x43, 0x41, 0x4e, 0x43, 0x45, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x1d, 0x0a, 0x19, 0x4f,
+	0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x55, 0x4e, 0x52,
+	0x45, 0x41, 0x43, 0x48, 0x41, 0x42, 0x4c, 0x45, 0x10, 0x06, 0x12, 0x1b, 0x0a, 0x17, 0x4f, 0x55,
+	0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x52, 0x50, 0x43, 0x5f,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x10, 0x07, 0x12, 0x25, 0x0a, 0x21, 0x4f, 0x55, 0x54, 0x43, 0x4f,
+	0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4d, 0x41, 0x4c, 0x46, 0x4f, 0x52, 0x4d,
+	0x45, 0x44, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55, 0x52, 0x45, 0x10, 0x08, 0x12, 0x24,
+	0x0a, 0x20, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f,
+	0x52, 0x45, 0x4a, 0x45, 0x43, 0x54, 0x45, 0x44, 0x5f, 0x53, 0x49, 0x47, 0x4e, 0x41, 0x54, 0x55,
+	0x52, 0x45, 0x10, 0x09, 0x12, 0x1f, 0x0a, 0x1b, 0x4f, 0x55, 0x54, 0x43, 0x4f, 0x4d, 0x45, 0x5f,
+	0x43, 0x4c, 0x41, 0x53, 0x53, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x43, 0x4f, 0x4e, 0x54, 0x41, 0x43,
+	0x54, 0x45, 0x44, 0x10, 0x0a, 0x32, 0xe9, 0x03, 0x0a, 0x11, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x6f, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x53, 0x0a, 0x0a, 0x53,
+	0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x20, 0x2e, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74,
+	0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x21, 0x2e, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d,
+	0x69, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00,
+	0x12, 0x4a, 0x0a, 0x07, 0x47, 0x65, 0x74, 0x54, 0x61, 0x73, 0x6b, 0x12, 0x1d, 0x2e, 0x61, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54,
+	0x61, 0x73, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x54, 0x61,
+	0x73, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x5f, 0x0a, 0x0e,
+	0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x12, 0x24,
+	0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x6b, 0x0a,
+	0x12, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63,
+	0x61, 0x74, 0x65, 0x12, 0x28, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x69, 0x74, 0x46, 0x6f, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x29, 0x2e,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x69, 0x74, 0x46, 0x6f, 0x72, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x00, 0x12, 0x65, 0x0a, 0x10, 0x4c, 0x69,
+	0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x26,
+	0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x27, 0x2e, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
+	0x74, 0x6f, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x43, 0x65, 0x72, 0x74, 0x69,
+	0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x00, 0x42, 0x2a, 0x5a, 0x28, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x4c, 0x61, 0x79, 0x72, 0x2d, 0x4c, 0x61, 0x62, 0x73, 0x2f, 0x74, 0x65, 0x61, 0x6c, 0x2f, 0x61,
+	0x70, 0x69, 0x2f, 0x73, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x2f, 0x76, 0x31, 0x62, 0x06, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_aggregator_proto_rawDescOnce sync.Once
+	file_aggregator_proto_rawDescData = file_aggregator_proto_rawDesc
+)
+
+func file_aggregator_proto_rawDescGZIP() []byte {
+	file_aggregator_proto_rawDescOnce.Do(func() {
+		file_aggregator_proto_rawDescData = protoimpl.X.CompressGZIP(file_aggregator_proto_rawDescData)
+	})
+	return file_aggregator_proto_rawDescData
+}
+
+var file_aggregator_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_aggregator_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_aggregator_proto_goTypes = []interface{}{
+	(TaskStatus)(0),                    // 0: aggregator.v1.TaskStatus
+	(AggregationMode)(0),               // 1: aggregator.v1.AggregationMode
+	(OutcomeClass)(0),                  // 2: aggregator.v1.OutcomeClass
+	(*TaskPolicy)(nil),                 // 3: aggregator.v1.TaskPolicy
+	(*SubmitTaskRequest)(nil),          // 4: aggregator.v1.SubmitTaskRequest
+	(*SubmitTaskResponse)(nil),         // 5: aggregator.v1.SubmitTaskResponse
+	(*GetTaskRequest)(nil),             // 6: aggregator.v1.GetTaskRequest
+	(*GetTaskResponse)(nil),            // 7: aggregator.v1.GetTaskResponse
+	(*GetCertificateRequest)(nil),      // 8: aggregator.v1.GetCertificateRequest
+	(*GetCertificateResponse)(nil),     // 9: aggregator.v1.GetCertificateResponse
+	(*WaitForCertificateRequest)(nil),  // 10: aggregator.v1.WaitForCertificateRequest
+	(*WaitForCertificateResponse)(nil), // 11: aggregator.v1.WaitForCertificateResponse
+	(*OperatorOutcome)(nil),            // 12: aggregator.v1.OperatorOutcome
+	(*TimeRange)(nil),                  // 13: aggregator.v1.TimeRange
+	(*ListCertificatesRequest)(nil),    // 14: aggregator.v1.ListCertificatesRequest
+	(*ListCertificatesResponse)(nil),   // 15: aggregator.v1.ListCertificatesResponse
+	(*G1Point)(nil),                    // 16: aggregator.v1.G1Point
+	(*G2Point)(nil),                    // 17: aggregator.v1.G2Point
+	(*NonSignerStakeIndices)(nil),      // 18: aggregator.v1.NonSignerStakeIndices
+	(*Certificate)(nil),                // 19: aggregator.v1.Certificate
+	(*timestamppb.Timestamp)(nil),      // 20: google.protobuf.Timestamp
+}
+var file_aggregator_proto_depIdxs = []int32{
+	1,  // 0: aggregator.v1.TaskPolicy.mode:type_name -> aggregator.v1.AggregationMode
+	3,  // 1: aggregator.v1.SubmitTaskRequest.policy:type_name -> aggregator.v1.TaskPolicy
+	0,  // 2: aggregator.v1.GetTaskResponse.status:type_name -> aggregator.v1.TaskStatus
+	20, // 3: aggregator.v1.GetTaskResponse.created_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: aggregator.v1.GetCertificateResponse.status:type_name -> aggregator.v1.TaskStatus
+	19, // 5: aggregator.v1.GetCertificateResponse.certificate:type_name -> aggregator.v1.Certificate
+	12, // 6: aggregator.v1.GetCertificateResponse.operators:type_name -> aggregator.v1.OperatorOutcome
+	0,  // 7: aggregator.v1.WaitForCertificateResponse.status:type_name -> aggregator.v1.TaskStatus
+	19, // 8: aggregator.v1.WaitForCertificateResponse.certificate:type_name -> aggregator.v1.Certificate
+	12, // 9: aggregator.v1.WaitForCertificateResponse.operators:type_name -> aggregator.v1.OperatorOutcome
+	2,  // 10: aggregator.v1.OperatorOutcome.class:type_name -> aggregator.v1.OutcomeClass
+	20, // 11: aggregator.v1.TimeRange.from:type_name -> google.protobuf.Timestamp
+	20, // 12: aggregator.v1.TimeRange.to:type_name -> google.protobuf.Timestamp
+	13, // 13: aggregator.v1.ListCertificatesRequest.created_at:type_name -> aggregator.v1.TimeRange
+	19, // 14: aggregator.v1.ListCertificatesResponse.certificates:type_name -> aggregator.v1.Certificate
+	16, // 15: aggregator.v1.Certificate.non_signers_pubkeys_g1:type_name -> aggregator.v1.G1Point
+	16, // 16: aggregator.v1.Certificate.quorum_apks_g1:type_name -> aggregator.v1.G1Point
+	17, // 17: aggregator.v1.Certificate.signers_apk_g2:type_name -> aggregator.v1.G2Point
+	16, // 18: aggregator.v1.Certificate.signers_agg_sig_g1:type_name -> aggregator.v1.G1Point
+	18, // 19: aggregator.v1.Certificate.non_signer_stake_indices:type_name -> aggregator.v1.NonSignerStakeIndices
+	4,  // 20: aggregator.v1.AggregatorService.SubmitTask:input_type -> aggregator.v1.SubmitTaskRequest
+	6,  // 21: aggregator.v1.AggregatorService.GetTask:input_type -> aggregator.v1.GetTaskRequest
+	8,  // 22: aggregator.v1.AggregatorService.GetCertificate:input_type -> aggregator.v1.GetCertificateRequest
+	10, // 23: aggregator.v1.AggregatorService.WaitForCertificate:input_type -> aggregator.v1.WaitForCertificateRequest
+	14, // 24: aggregator.v1.AggregatorService.ListCertificates:input_type -> aggregator.v1.ListCertificatesRequest
+	5,  // 25: aggregator.v1.AggregatorService.SubmitTask:output_type -> aggregator.v1.SubmitTaskResponse
+	7,  // 26: aggregator.v1.AggregatorService.GetTask:output_type -> aggregator.v1.GetTaskResponse
+	9,  // 27: aggregator.v1.AggregatorService.GetCertificate:output_type -> aggregator.v1.GetCertificateResponse
+	11, // 28: aggregator.v1.AggregatorService.WaitForCertificate:output_type -> aggregator.v1.WaitForCertificateResponse
+	15, // 29: aggregator.v1.AggregatorService.ListCertificates:output_type -> aggregator.v1.ListCertificatesResponse
+	25, // [25:30] is the sub-list for method output_type
+	20, // [20:25] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
+}
+
+func init() { file_aggregator_proto_init() }
+func file_aggregator_proto_init() {
+	if File_aggregator_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_aggregator_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TaskPolicy); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitTaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubmitTaskResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetTaskResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCertificateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetCertificateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitForCertificateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WaitForCertificateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OperatorOutcome); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TimeRange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCertificatesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListCertificatesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*G1Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*G2Point); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NonSignerStakeIndices); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_aggregator_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Certificate); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_aggregator_proto_msgTypes[11].OneofWrappers = []interface{}{
+		(*ListCertificatesRequest_TaskResponseDigest)(nil),
+		(*ListCertificatesRequest_CreatedAt)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_aggregator_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_aggregator_proto_goTypes,
+		DependencyIndexes: file_aggregator_proto_depIdxs,
+		EnumInfos:         file_aggregator_proto_enumTypes,
+		MessageInfos:      file_aggregator_proto_msgTypes,
+	}.Build()
+	File_aggregator_proto = out.File
+	file_aggregator_proto_rawDesc = nil
+	file_aggregator_proto_goTypes = nil
+	file_aggregator_proto_depIdxs = nil
+}
@@ -0,0 +1,9 @@
+// Package swagger embeds the OpenAPI document generated from the service definitions
+package swagger
+
+import _ "embed"
+
+// Spec is the OpenAPI v2 document of the node and aggregator services, in YAML
+//
+//go:embed grpc.swagger.yaml
+var Spec []byte
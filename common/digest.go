@@ -0,0 +1,64 @@
+package common
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TaskResponseV1Tag versions the digests computed by SigningDomain.Digest. It must match
+// TASK_RESPONSE_V1_TAG of the verifier contract.
+var TaskResponseV1Tag = crypto.Keccak256Hash([]byte("teal.TaskResponse.v1"))
+
+// TaskResponse is a response together with the task it was produced for. The aggregator
+// hands it to the bls aggregation service so that the hash function can bind the digest
+// to the task.
+type TaskResponse struct {
+	TaskIndex            uint32
+	ReferenceBlockNumber uint32
+	Data                 []byte
+}
+
+// SigningDomain binds signed digests to a deployment, so that a signature produced for
+// one chain, verifier or task can't be replayed for another one
+type SigningDomain struct {
+	ChainId         *big.Int
+	VerifierAddress gethcommon.Address
+}
+
+// Digest returns
+//
+//	keccak256(abi.encodePacked(TASK_RESPONSE_V1_TAG, chainId, verifier, taskIndex, referenceBlockNumber, keccak256(response)))
+//
+// as computed by taskResponseDigestV1 of the verifier contract
+func (d SigningDomain) Digest(taskIndex uint32, referenceBlockNumber uint32, response []byte) [32]byte {
+	var taskIndexBytes, referenceBlockNumberBytes [4]byte
+	binary.BigEndian.PutUint32(taskIndexBytes[:], taskIndex)
+	binary.BigEndian.PutUint32(referenceBlockNumberBytes[:], referenceBlockNumber)
+
+	return [32]byte(crypto.Keccak256(
+		TaskResponseV1Tag[:],
+		math.U256Bytes(new(big.Int).Set(d.ChainId)),
+		d.VerifierAddress[:],
+		taskIndexBytes[:],
+		referenceBlockNumberBytes[:],
+		crypto.Keccak256(response),
+	))
+}
+
+// HashFn returns the hash function to create the bls aggregation service with, it
+// requires TaskResponse values
+func (d SigningDomain) HashFn() types.TaskResponseHashFunction {
+	return func(response types.TaskResponse) (types.TaskResponseDigest, error) {
+		taskResponse, ok := response.(TaskResponse)
+		if !ok {
+			return types.TaskResponseDigest{}, fmt.Errorf("response is not a TaskResponse")
+		}
+		return d.Digest(taskResponse.TaskIndex, taskResponse.ReferenceBlockNumber, taskResponse.Data), nil
+	}
+}
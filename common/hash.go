@@ -7,9 +7,16 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// Keccak256HashFn is the legacy digest keccak256(response), which is not bound to a
+// deployment or task. See SigningDomain for the digest that is.
 func Keccak256HashFn(response types.TaskResponse) (types.TaskResponseDigest, error) {
-	responseBytes, ok := response.([]byte)
-	if !ok {
+	var responseBytes []byte
+	switch response := response.(type) {
+	case []byte:
+		responseBytes = response
+	case TaskResponse:
+		responseBytes = response.Data
+	default:
 		return types.TaskResponseDigest{}, fmt.Errorf("response is not a byte array")
 	}
 
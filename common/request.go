@@ -0,0 +1,48 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+)
+
+// certifyRequestDomain separates certify request digests from other signed messages
+const certifyRequestDomain = "teal.CertifyRequest"
+
+// CertifyRequestDigest is the digest the aggregator signs to authenticate a certify request
+func CertifyRequestDigest(taskIndex uint32, referenceBlockNumber uint32, data []byte) [32]byte {
+	var taskIndexBytes, referenceBlockNumberBytes [4]byte
+	binary.BigEndian.PutUint32(taskIndexBytes[:], taskIndex)
+	binary.BigEndian.PutUint32(referenceBlockNumberBytes[:], referenceBlockNumber)
+	return [32]byte(crypto.Keccak256([]byte(certifyRequestDomain), taskIndexBytes[:], referenceBlockNumberBytes[:], data))
+}
+
+// SignCertifyRequest sets the aggregator signature of req
+func SignCertifyRequest(req *v1.CertifyRequest, key *ecdsa.PrivateKey) error {
+	digest := CertifyRequestDigest(req.TaskIndex, req.ReferenceBlockNumber, req.Data)
+	signature, err := crypto.Sign(digest[:], key)
+	if err != nil {
+		return fmt.Errorf("failed to sign certify request: %w", err)
+	}
+	req.AggregatorSignature = signature
+	return nil
+}
+
+// RecoverCertifyRequestSigner returns the address of the aggregator that signed req
+func RecoverCertifyRequestSigner(req *v1.CertifyRequest) (gethcommon.Address, error) {
+	if len(req.AggregatorSignature) == 0 {
+		return gethcommon.Address{}, errors.New("request is not signed")
+	}
+	digest := CertifyRequestDigest(req.TaskIndex, req.ReferenceBlockNumber, req.Data)
+	pubkey, err := crypto.SigToPub(digest[:], req.AggregatorSignature)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("invalid aggregator signature: %w", err)
+	}
+	return crypto.PubkeyToAddress(*pubkey), nil
+}
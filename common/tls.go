@@ -0,0 +1,288 @@
+package common
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// TLSConfig holds the paths of the certificates used to secure the connection between
+// the aggregator and the nodes. Files are reloaded when they change, so that certificates
+// can be rotated without a restart.
+type TLSConfig struct {
+	// CertFile and KeyFile are the certificate presented to the peer. They are required on
+	// the server and optional on the client, where they are only needed for mutual TLS.
+	CertFile string
+	KeyFile  string
+	// CAFile holds the PEM encoded certificates the peer's certificate is verified against.
+	// On the client, the system's certificate pool is used if it is empty.
+	CAFile string
+	// RequireClientCert makes the server reject clients without a certificate signed by CAFile
+	RequireClientCert bool
+	// ServerName overrides the name the client verifies the server's certificate against
+	ServerName string
+}
+
+// NewServerTLSConfig creates the configuration of a server that reloads its certificate
+// and the client CAs when their files change
+func NewServerTLSConfig(config TLSConfig) (*tls.Config, error) {
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("server TLS requires a certificate and a key file")
+	}
+	if config.RequireClientCert && config.CAFile == "" {
+		return nil, errors.New("requiring client certificates requires a CA file")
+	}
+
+	certificate := newFileReloader(loadKeyPair(config.CertFile, config.KeyFile), config.CertFile, config.KeyFile)
+	var clientCAs *fileReloader[*x509.CertPool]
+	if config.CAFile != "" {
+		clientCAs = newFileReloader(loadCertPool(config.CAFile), config.CAFile)
+	}
+	// fail at startup rather than on the first handshake
+	if _, err := certificate.get(); err != nil {
+		return nil, err
+	}
+
+	clientAuth := tls.NoClientCert
+	if clientCAs != nil {
+		clientAuth = tls.VerifyClientCertIfGiven
+		if config.RequireClientCert {
+			clientAuth = tls.RequireAndVerifyClientCert
+		}
+	}
+
+	return &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		GetConfigForClient: func(*tls.ClientHelloInfo) (*tls.Config, error) {
+			cert, err := certificate.get()
+			if err != nil {
+				return nil, err
+			}
+			serverConfig := &tls.Config{
+				MinVersion:   tls.VersionTLS12,
+				Certificates: []tls.Certificate{*cert},
+				ClientAuth:   clientAuth,
+				NextProtos:   []string{"h2"},
+			}
+			if clientCAs != nil {
+				serverConfig.ClientCAs, err = clientCAs.get()
+				if err != nil {
+					return nil, err
+				}
+			}
+			return serverConfig, nil
+		},
+	}, nil
+}
+
+// NewClientTLSConfig creates the configuration of a client that reloads its certificate
+// and the CAs it verifies servers against when their files change. With a CA file, the
+// server's certificate is verified against config.ServerName, or the server name of the
+// connection if it is empty. Clients dialing IP addresses, for which no server name is
+// sent, must set config.ServerName or use NewClientCredentials.
+func NewClientTLSConfig(config TLSConfig) (*tls.Config, error) {
+	newConfig, err := newClientTLSConfigFn(config)
+	if err != nil {
+		return nil, err
+	}
+	return newConfig(config.ServerName), nil
+}
+
+// NewClientCredentials creates gRPC transport credentials like NewClientTLSConfig, which
+// verify the certificate of every server against the host it is dialed at, unless
+// config.ServerName overrides it
+func NewClientCredentials(config TLSConfig) (credentials.TransportCredentials, error) {
+	newConfig, err := newClientTLSConfigFn(config)
+	if err != nil {
+		return nil, err
+	}
+	return &clientCredentials{
+		TransportCredentials: credentials.NewTLS(newConfig(config.ServerName)),
+		serverName:           config.ServerName,
+		newConfig:            newConfig,
+	}, nil
+}
+
+// newClientTLSConfigFn loads the files of config and returns a function creating client
+// configurations verifying the server's certificate against a server name
+func newClientTLSConfigFn(config TLSConfig) (func(serverName string) *tls.Config, error) {
+	if (config.CertFile == "") != (config.KeyFile == "") {
+		return nil, errors.New("client certificate and key file must be set together")
+	}
+
+	var certificate *fileReloader[*tls.Certificate]
+	if config.CertFile != "" {
+		certificate = newFileReloader(loadKeyPair(config.CertFile, config.KeyFile), config.CertFile, config.KeyFile)
+		if _, err := certificate.get(); err != nil {
+			return nil, err
+		}
+	}
+	var rootCAs *fileReloader[*x509.CertPool]
+	if config.CAFile != "" {
+		rootCAs = newFileReloader(loadCertPool(config.CAFile), config.CAFile)
+		if _, err := rootCAs.get(); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(serverName string) *tls.Config {
+		clientConfig := &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: serverName,
+		}
+		if certificate != nil {
+			clientConfig.GetClientCertificate = func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+				return certificate.get()
+			}
+		}
+		if rootCAs != nil {
+			// the default verification uses a fixed pool, so it is replaced with one against
+			// the current pool. VerifyConnection is still called with InsecureSkipVerify set.
+			clientConfig.InsecureSkipVerify = true
+			clientConfig.VerifyConnection = func(state tls.ConnectionState) error {
+				return verifyServerCertificate(state, serverName, rootCAs)
+			}
+		}
+		return clientConfig
+	}, nil
+}
+
+// verifyServerCertificate verifies the certificate of a server against serverName, a DNS
+// name or an IP address, or the server name of the connection if it is empty
+func verifyServerCertificate(state tls.ConnectionState, serverName string, rootCAs *fileReloader[*x509.CertPool]) error {
+	if serverName == "" {
+		serverName = state.ServerName
+	}
+	if serverName == "" {
+		return errors.New("no server name to verify the server certificate against")
+	}
+	roots, err := rootCAs.get()
+	if err != nil {
+		return err
+	}
+	if len(state.PeerCertificates) == 0 {
+		return errors.New("server presented no certificate")
+	}
+	intermediates := x509.NewCertPool()
+	for _, cert := range state.PeerCertificates[1:] {
+		intermediates.AddCert(cert)
+	}
+	// DNSName is matched against the IP SANs if it is an IP address
+	_, err = state.PeerCertificates[0].Verify(x509.VerifyOptions{
+		DNSName:       serverName,
+		Roots:         roots,
+		Intermediates: intermediates,
+	})
+	return err
+}
+
+// clientCredentials creates the TLS configuration of each connection for the host it
+// is dialed at, which the configuration's verification can't otherwise know about
+type clientCredentials struct {
+	credentials.TransportCredentials
+	serverName string
+	newConfig  func(serverName string) *tls.Config
+}
+
+func (c *clientCredentials) ClientHandshake(ctx context.Context, authority string, rawConn net.Conn) (net.Conn, credentials.AuthInfo, error) {
+	serverName := c.serverName
+	if serverName == "" {
+		serverName = authority
+		if host, _, err := net.SplitHostPort(authority); err == nil {
+			serverName = host
+		}
+	}
+	return credentials.NewTLS(c.newConfig(serverName)).ClientHandshake(ctx, authority, rawConn)
+}
+
+func (c *clientCredentials) Clone() credentials.TransportCredentials {
+	return &clientCredentials{
+		TransportCredentials: c.TransportCredentials.Clone(),
+		serverName:           c.serverName,
+		newConfig:            c.newConfig,
+	}
+}
+
+func loadKeyPair(certFile, keyFile string) func() (*tls.Certificate, error) {
+	return func() (*tls.Certificate, error) {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key pair: %w", err)
+		}
+		return &cert, nil
+	}
+}
+
+func loadCertPool(caFile string) func() (*x509.CertPool, error) {
+	return func() (*x509.CertPool, error) {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		return pool, nil
+	}
+}
+
+// fileReloader caches a value loaded from files and loads it again when any of them
+// was modified. If reloading fails, the previously loaded value is kept.
+type fileReloader[T any] struct {
+	paths []string
+	load  func() (T, error)
+
+	mu       sync.Mutex
+	value    T
+	loaded   bool
+	modTimes []time.Time
+}
+
+func newFileReloader[T any](load func() (T, error), paths ...string) *fileReloader[T] {
+	return &fileReloader[T]{
+		paths:    paths,
+		load:     load,
+		modTimes: make([]time.Time, len(paths)),
+	}
+}
+
+func (r *fileReloader[T]) get() (T, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	modTimes := make([]time.Time, len(r.paths))
+	changed := !r.loaded
+	for i, path := range r.paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			if r.loaded {
+				return r.value, nil
+			}
+			return r.value, err
+		}
+		modTimes[i] = info.ModTime()
+		changed = changed || !modTimes[i].Equal(r.modTimes[i])
+	}
+	if !changed {
+		return r.value, nil
+	}
+
+	value, err := r.load()
+	if err != nil {
+		if r.loaded {
+			return r.value, nil
+		}
+		return value, err
+	}
+	r.value, r.loaded, r.modTimes = value, true, modTimes
+	return value, nil
+}
@@ -0,0 +1,249 @@
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newTestCA(t *testing.T) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{cert: cert, key: key}
+}
+
+// issue writes a certificate for hosts, or localhost if there are none, signed by the
+// CA and its key to dir
+func (ca *testCA) issue(t *testing.T, dir, name string, serial int64, hosts ...string) (certFile, keyFile string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	if len(hosts) == 0 {
+		hosts = []string{"localhost"}
+	}
+	for _, host := range hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			template.IPAddresses = append(template.IPAddresses, ip)
+		} else {
+			template.DNSNames = append(template.DNSNames, host)
+		}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, name+".crt")
+	keyFile = filepath.Join(dir, name+".key")
+	writeFile(t, certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	writeFile(t, keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+	return certFile, keyFile
+}
+
+func (ca *testCA) write(t *testing.T, dir string) string {
+	caFile := filepath.Join(dir, "ca.crt")
+	writeFile(t, caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.cert.Raw}))
+	return caFile
+}
+
+// writeFile writes data with a modification time that differs from the previous one
+func writeFile(t *testing.T, path string, data []byte) {
+	modTime := time.Now()
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime().Add(time.Second)
+	}
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+// handshake connects client to server and returns the certificate presented by the server
+func handshake(serverConfig, clientConfig *tls.Config) (*x509.Certificate, error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		server := tls.Server(serverConn, serverConfig)
+		serverErr <- server.Handshake()
+		server.Close()
+	}()
+
+	client := tls.Client(clientConn, clientConfig)
+	if err := client.Handshake(); err != nil {
+		return nil, err
+	}
+	// TLS 1.3 clients complete the handshake before the server verified their certificate,
+	// a rejection is only noticed once reading
+	client.Read(make([]byte, 1))
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	return client.ConnectionState().PeerCertificates[0], nil
+}
+
+// handshakeCredentials connects client, dialing authority, to server and returns the
+// certificate presented by the server
+func handshakeCredentials(serverConfig *tls.Config, client credentials.TransportCredentials, authority string) (*x509.Certificate, error) {
+	serverConn, clientConn := net.Pipe()
+	defer serverConn.Close()
+	defer clientConn.Close()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		server := tls.Server(serverConn, serverConfig)
+		serverErr <- server.Handshake()
+		server.Close()
+	}()
+
+	conn, _, err := client.ClientHandshake(context.Background(), authority, clientConn)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	conn.Read(make([]byte, 1))
+	if err := <-serverErr; err != nil {
+		return nil, err
+	}
+	return conn.(*tls.Conn).ConnectionState().PeerCertificates[0], nil
+}
+
+func TestTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t)
+	caFile := ca.write(t, dir)
+	serverCert, serverKey := ca.issue(t, dir, "server", 10)
+	clientCert, clientKey := ca.issue(t, dir, "client", 20)
+
+	t.Run("server certificate is verified and reloaded", func(t *testing.T) {
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: serverKey})
+		require.NoError(t, err)
+		clientConfig, err := NewClientTLSConfig(TLSConfig{CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+
+		cert, err := handshake(serverConfig, clientConfig)
+		require.NoError(t, err)
+		assert.Equal(t, int64(10), cert.SerialNumber.Int64())
+
+		ca.issue(t, dir, "server", 11)
+		cert, err = handshake(serverConfig, clientConfig)
+		require.NoError(t, err)
+		assert.Equal(t, int64(11), cert.SerialNumber.Int64())
+	})
+
+	t.Run("server certificate from another CA is rejected", func(t *testing.T) {
+		otherDir := t.TempDir()
+		otherCert, otherKey := newTestCA(t).issue(t, otherDir, "server", 30)
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: otherCert, KeyFile: otherKey})
+		require.NoError(t, err)
+		clientConfig, err := NewClientTLSConfig(TLSConfig{CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+
+		_, err = handshake(serverConfig, clientConfig)
+		assert.Error(t, err)
+	})
+
+	t.Run("server certificate for another name is rejected", func(t *testing.T) {
+		otherCert, otherKey := ca.issue(t, t.TempDir(), "other", 40, "other.example")
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: otherCert, KeyFile: otherKey})
+		require.NoError(t, err)
+		clientConfig, err := NewClientTLSConfig(TLSConfig{CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+
+		_, err = handshake(serverConfig, clientConfig)
+		assert.Error(t, err)
+	})
+
+	t.Run("server certificate is verified against the dialed IP address", func(t *testing.T) {
+		ipCert, ipKey := ca.issue(t, t.TempDir(), "ip", 50, "127.0.0.1")
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: ipCert, KeyFile: ipKey})
+		require.NoError(t, err)
+		client, err := NewClientCredentials(TLSConfig{CAFile: caFile})
+		require.NoError(t, err)
+
+		cert, err := handshakeCredentials(serverConfig, client, "127.0.0.1:9090")
+		require.NoError(t, err)
+		assert.Equal(t, int64(50), cert.SerialNumber.Int64())
+
+		_, err = handshakeCredentials(serverConfig, client, "127.0.0.2:9090")
+		assert.Error(t, err)
+		_, err = handshakeCredentials(serverConfig, client, "localhost:9090")
+		assert.Error(t, err)
+	})
+
+	t.Run("server certificate can't be verified without a server name", func(t *testing.T) {
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: serverKey})
+		require.NoError(t, err)
+		clientConfig, err := NewClientTLSConfig(TLSConfig{CAFile: caFile})
+		require.NoError(t, err)
+
+		_, err = handshake(serverConfig, clientConfig)
+		assert.Error(t, err)
+	})
+
+	t.Run("client certificate can be required", func(t *testing.T) {
+		serverConfig, err := NewServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: serverKey, CAFile: caFile, RequireClientCert: true})
+		require.NoError(t, err)
+
+		withoutCert, err := NewClientTLSConfig(TLSConfig{CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+		_, err = handshake(serverConfig, withoutCert)
+		assert.Error(t, err)
+
+		withCert, err := NewClientTLSConfig(TLSConfig{CertFile: clientCert, KeyFile: clientKey, CAFile: caFile, ServerName: "localhost"})
+		require.NoError(t, err)
+		_, err = handshake(serverConfig, withCert)
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid configurations are rejected", func(t *testing.T) {
+		_, err := NewServerTLSConfig(TLSConfig{})
+		assert.Error(t, err)
+		_, err = NewServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: serverKey, RequireClientCert: true})
+		assert.Error(t, err)
+		_, err = NewServerTLSConfig(TLSConfig{CertFile: serverCert, KeyFile: filepath.Join(dir, "missing.key")})
+		assert.Error(t, err)
+		_, err = NewClientTLSConfig(TLSConfig{CertFile: clientCert})
+		assert.Error(t, err)
+	})
+}
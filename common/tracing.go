@@ -0,0 +1,104 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+// Tracing exporters, as selected by TracingConfig.Exporter
+const (
+	TracingExporterNone   = "none"
+	TracingExporterStdout = "stdout"
+	TracingExporterFile   = "file"
+	TracingExporterOtlp   = "otlp"
+)
+
+// TracePropagator propagates trace context between the aggregator and the nodes in the
+// W3C traceparent and tracestate gRPC metadata
+var TracePropagator propagation.TextMapPropagator = propagation.TraceContext{}
+
+// TracingConfig selects where the spans of a process are exported to
+type TracingConfig struct {
+	// Exporter is one of the TracingExporter constants, tracing is disabled if it is
+	// empty or TracingExporterNone
+	Exporter string
+	// File is the file spans are written to as JSON by TracingExporterFile
+	File string
+	// OtlpEndpoint is the URL of the OTLP/gRPC collector of TracingExporterOtlp, the
+	// connection is insecure if its scheme is http
+	OtlpEndpoint string
+	// ServiceName identifies the process in the exported spans
+	ServiceName string
+}
+
+// SetupTracing installs a tracer provider exporting to config's exporter and the W3C
+// trace context propagator as the global ones, which the aggregator and the nodes use
+// unless they are given a tracer provider. The returned function flushes and stops the
+// exporter.
+func SetupTracing(ctx context.Context, config TracingConfig) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(TracePropagator)
+	if config.Exporter == "" || config.Exporter == TracingExporterNone {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, closeOutput, err := newSpanExporter(ctx, config)
+	if err != nil {
+		return nil, err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName(config.ServiceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tracing resource: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		return errors.Join(provider.Shutdown(ctx), closeOutput())
+	}, nil
+}
+
+// newSpanExporter creates the exporter of config and a function closing its output
+func newSpanExporter(ctx context.Context, config TracingConfig) (sdktrace.SpanExporter, func() error, error) {
+	noClose := func() error { return nil }
+	switch config.Exporter {
+	case TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, noClose, err
+	case TracingExporterFile:
+		if config.File == "" {
+			return nil, nil, errors.New("the file tracing exporter requires a file")
+		}
+		file, err := os.OpenFile(config.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open tracing file: %w", err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(io.Writer(file)))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file.Close, nil
+	case TracingExporterOtlp:
+		if config.OtlpEndpoint == "" {
+			return nil, nil, errors.New("the otlp tracing exporter requires an endpoint")
+		}
+		exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(config.OtlpEndpoint))
+		return exporter, noClose, err
+	default:
+		return nil, nil, fmt.Errorf("unknown tracing exporter %q", config.Exporter)
+	}
+}
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+)
+
+func TestSetupTracing(t *testing.T) {
+	t.Run("file exporter", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "traces.jsonl")
+		shutdown, err := SetupTracing(context.Background(), TracingConfig{
+			Exporter:    TracingExporterFile,
+			File:        file,
+			ServiceName: "test",
+		})
+		require.NoError(t, err)
+
+		_, span := otel.Tracer("test").Start(context.Background(), "GetCertificate")
+		span.End()
+		require.NoError(t, shutdown(context.Background()))
+
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		var exported struct {
+			Name        string
+			SpanContext struct{ TraceID string }
+		}
+		require.NoError(t, json.Unmarshal(data, &exported))
+		assert.Equal(t, "GetCertificate", exported.Name)
+		assert.Equal(t, span.SpanContext().TraceID().String(), exported.SpanContext.TraceID)
+	})
+
+	t.Run("invalid configuration", func(t *testing.T) {
+		_, err := SetupTracing(context.Background(), TracingConfig{Exporter: "jaeger"})
+		assert.Error(t, err)
+		_, err = SetupTracing(context.Background(), TracingConfig{Exporter: TracingExporterFile})
+		assert.Error(t, err)
+		_, err = SetupTracing(context.Background(), TracingConfig{Exporter: TracingExporterOtlp})
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package common
+
+import "runtime/debug"
+
+const modulePath = "github.com/Layr-Labs/teal"
+
+// Version is the build version of the binary, it can be set with
+// -ldflags "-X github.com/Layr-Labs/teal/common.Version=v1.2.3"
+var Version = ""
+
+// BuildVersion returns Version if set. Otherwise it returns the version of this module the
+// binary was built with, or the VCS revision if the binary was built from this module.
+func BuildVersion() string {
+	if Version != "" {
+		return Version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == modulePath {
+			return dep.Version
+		}
+	}
+	if info.Main.Path == modulePath && info.Main.Version != "(devel)" && info.Main.Version != "" {
+		return info.Main.Version
+	}
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			return setting.Value
+		}
+	}
+	return "unknown"
+}
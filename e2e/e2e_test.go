@@ -94,7 +94,11 @@ func TestIntegrationBlsAgg(t *testing.T) {
 			operatorsInfoService,
 			logger,
 		)
-		blsAggService := blsagg.NewBlsAggregatorService(avsRegistryService, common.Keccak256HashFn, logger)
+		// no verifier is deployed, the digests are bound to the service manager instead
+		chainId, err := ethHttpClient.ChainID(context.Background())
+		require.NoError(t, err)
+		signingDomain := common.SigningDomain{ChainId: chainId, VerifierAddress: contractAddrs.ServiceManager}
+		blsAggService := blsagg.NewBlsAggregatorService(avsRegistryService, signingDomain.HashFn(), logger)
 
 		// register operator
 		quorumNumbers := testData.Input.QuorumNumbers
@@ -109,10 +113,13 @@ func TestIntegrationBlsAgg(t *testing.T) {
 		require.NoError(t, err)
 
 		evenLovingNode := e2e.NewEvenLovingNode(server.Config{
-			ServicePort: 8080,
-			BlsKeyPair:  blsKeyPair,
+			ServicePort:   8080,
+			BlsKeyPair:    blsKeyPair,
+			SigningDomain: signingDomain,
 		})
-		go evenLovingNode.Start()
+		go evenLovingNode.Start(context.Background())
+		defer evenLovingNode.Stop(context.Background())
+		<-evenLovingNode.Ready()
 
 		// create the task related parameters: RBN, quorumThresholdPercentages, taskIndex and taskResponse
 		curBlockNum, err := ethHttpClient.BlockNumber(context.Background())
@@ -130,6 +137,7 @@ func TestIntegrationBlsAgg(t *testing.T) {
 			avsRegistryService,
 			blsAggService,
 			operatorrequester.NewOperatorRequester(logger),
+			signingDomain,
 		)
 
 		_, err = aggregator.GetCertificate(
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"math/big"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/chainio/clients/avsregistry"
@@ -22,11 +23,14 @@ import (
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethtypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/Layr-Labs/eigensdk-go/services/operatorsinfo"
 	"github.com/Layr-Labs/teal/aggregator"
 	operatorrequester "github.com/Layr-Labs/teal/aggregator/operator_requester"
+	"github.com/Layr-Labs/teal/aggregator/server"
+	"github.com/Layr-Labs/teal/aggregator/store"
 	"github.com/Layr-Labs/teal/common"
 	"github.com/Layr-Labs/teal/example/utils"
 	"github.com/prometheus/client_golang/prometheus"
@@ -47,6 +51,18 @@ func main() {
 		&utils.EthUrlFlag,
 		&utils.AvsDeploymentPathFlag,
 		&utils.EcdsaPrivateKeyFlag,
+		&utils.DataDirFlag,
+		&utils.MetricsPortFlag,
+		&utils.AggregatorServicePortFlag,
+		&utils.AggregatorHttpPortFlag,
+		&utils.TracingExporterFlag,
+		&utils.TracingFileFlag,
+		&utils.TracingOtlpEndpointFlag,
+		&utils.TLSCertFileFlag,
+		&utils.TLSKeyFileFlag,
+		&utils.TLSCAFileFlag,
+		&utils.SigningDomainVerifierFlag,
+		&utils.LegacyDigestFlag,
 	}
 
 	app.Action = start
@@ -68,6 +84,12 @@ func start(c *cli.Context) error {
 
 	logger := logging.NewTextSLogger(os.Stdout, &logging.SLoggerOptions{Level: slog.LevelInfo})
 
+	shutdownTracing, err := utils.SetupTracing(c, "teal-aggregator")
+	if err != nil {
+		panic(err)
+	}
+	defer shutdownTracing(context.Background())
+
 	chainid, err := client.ChainID(ctx)
 	if err != nil {
 		panic(err)
@@ -140,19 +162,99 @@ func start(c *cli.Context) error {
 		logger,
 	)
 
+	// nodes sign digests bound to the chain and verifier the certificates are verified by,
+	// unless they were started with the legacy digest
+	signingDomain, err := utils.SigningDomain(c, chainid, avsDeployment.CertificateVerifier)
+	if err != nil {
+		panic(err)
+	}
+	legacyDigest := c.Bool(utils.LegacyDigestFlag.Name)
+	hashFunction := signingDomain.HashFn()
+	if legacyDigest {
+		hashFunction = common.Keccak256HashFn
+	}
+
 	blsAggService := blsagg.NewBlsAggregatorService(
 		avsRegistryService,
-		common.Keccak256HashFn,
+		hashFunction,
+		logger,
+	)
+
+	dataDir := c.String(utils.DataDirFlag.Name)
+	taskRegistry, err := store.NewFileTaskRegistry(logger, filepath.Join(dataDir, "tasks.jsonl"))
+	if err != nil {
+		panic(err)
+	}
+	defer taskRegistry.Close()
+
+	certificateStore, err := store.NewFileCertificateStore(filepath.Join(dataDir, "certificates"))
+	if err != nil {
+		panic(err)
+	}
+
+	// nodes authenticate the aggregator by the key it sends transactions with
+	requesterOpts := []operatorrequester.Option{operatorrequester.WithSigningKey(ecdsaPrivateKey)}
+	if c.String(utils.TLSCAFileFlag.Name) != "" {
+		tlsCredentials, err := common.NewClientCredentials(common.TLSConfig{
+			CertFile: c.String(utils.TLSCertFileFlag.Name),
+			KeyFile:  c.String(utils.TLSKeyFileFlag.Name),
+			CAFile:   c.String(utils.TLSCAFileFlag.Name),
+		})
+		if err != nil {
+			panic(err)
+		}
+		requesterOpts = append(requesterOpts, operatorrequester.WithTLS(tlsCredentials))
+	}
+
+	operatorRequester, err := operatorrequester.NewRetryingRequester(
 		logger,
+		operatorrequester.NewOperatorRequester(logger, requesterOpts...),
+		operatorrequester.DefaultRetryPolicy,
 	)
+	if err != nil {
+		panic(err)
+	}
 
-	aggregator := aggregator.NewAggregatorService(
+	aggregatorOpts := []aggregator.Option{
+		aggregator.WithTaskRegistry(taskRegistry),
+		aggregator.WithCertificateStore(certificateStore),
+		aggregator.WithMetrics(aggregator.NewMetrics(reg)),
+		// leave the task time to aggregate the operators that answered when others hang
+		aggregator.WithOperatorTimeout(5 * time.Second),
+	}
+	if legacyDigest {
+		aggregatorOpts = append(aggregatorOpts, aggregator.WithLegacyDigest())
+	}
+	aggregatorService := aggregator.NewAggregatorService(
 		logger,
 		avsRegistryService,
 		blsAggService,
-		operatorrequester.NewOperatorRequester(logger),
+		operatorRequester,
+		signingDomain,
+		aggregatorOpts...,
 	)
 
+	if port := c.Int(utils.MetricsPortFlag.Name); port != 0 {
+		go func() {
+			if err := utils.ServeMetrics(port, reg); err != nil {
+				logger.Error("Failed to serve metrics", "error", err)
+			}
+		}()
+	}
+
+	// besides certifying its own tasks, the aggregator can take tasks submitted by clients
+	if port := c.Int(utils.AggregatorServicePortFlag.Name); port != 0 {
+		aggregatorServer := server.NewServer(server.Config{
+			ServicePort: port,
+			HttpPort:    c.Int(utils.AggregatorHttpPortFlag.Name),
+		}, logger, aggregatorService)
+		go func() {
+			if err := aggregatorServer.Start(); err != nil {
+				logger.Error("Failed to serve the aggregator API", "error", err)
+			}
+		}()
+	}
+
 	certVerifier, err := minimalCertificateVerifier.NewContractMinimalCertificateVerifier(
 		avsDeployment.CertificateVerifier,
 		client,
@@ -173,13 +275,11 @@ func start(c *cli.Context) error {
 	quorumThreshold := types.QuorumThresholdPercentage(uint8(new(big.Int).Div(new(big.Int).Mul(threshold, big.NewInt(100)), denominator).Uint64()) + 1)
 
 	quorumNumber := types.QuorumNum(0)
-	requestNumber := uint32(0)
 
 	// on a ticker every 30s, get the certificate
 	for {
 		func() {
 			defer time.Sleep(5 * time.Second)
-			requestNumber++
 
 			currentBlockNumber, err := client.BlockNumber(ctx)
 			if err != nil {
@@ -202,17 +302,28 @@ func start(c *cli.Context) error {
 
 			logger.Info("Requesting certificate of totalShares()", "callBlockNumber", callBlockNumber)
 
-			resp, err := aggregator.GetCertificate(
-				ctx,
-				types.TaskIndex(requestNumber),
-				uint32(referenceBlockNumber),
-				quorumNumber,
-				quorumThreshold,
-				request,
-				10*time.Second,
-			)
+			task, err := aggregatorService.CreateTask(ctx, aggregator.TaskRequest{
+				ReferenceBlockNumber:       uint32(referenceBlockNumber),
+				QuorumNumbers:              types.QuorumNums{quorumNumber},
+				QuorumThresholdPercentages: types.QuorumThresholdPercentages{quorumThreshold},
+				Data:                       request,
+				TimeToExpiry:               10 * time.Second,
+			})
+			if err != nil {
+				logger.Error("Failed to create task", "error", err)
+				return
+			}
+
+			resp, err := aggregatorService.CertifyTask(ctx, task)
+			if resp != nil {
+				for _, outcome := range resp.Operators {
+					if outcome.Class != aggregator.OutcomeSigned {
+						logger.Warn("Operator did not sign", "taskIndex", task.TaskIndex, "operatorId", outcome.OperatorId, "socket", outcome.Socket, "outcome", outcome.Class, "latency", outcome.Latency, "error", outcome.Err)
+					}
+				}
+			}
 			if err != nil {
-				logger.Error("Failed to get certificate", "error", err)
+				logger.Error("Failed to get certificate", "taskIndex", task.TaskIndex, "error", err)
 				return
 			}
 
@@ -221,22 +332,35 @@ func start(c *cli.Context) error {
 				logger.Error("Failed to get tx opts", "error", err)
 				return
 			}
-			tx, err := certVerifier.VerifyCertificate(
-				txOpts,
-				resp.TaskResponse.([]byte),
-				[]byte{byte(quorumNumber)},
-				uint32(referenceBlockNumber),
-				minimalCertificateVerifier.IBLSSignatureCheckerNonSignerStakesAndSignature{
-					NonSignerQuorumBitmapIndices: resp.NonSignerQuorumBitmapIndices,
-					NonSignerPubkeys:             utils.ToBN254G1Points(resp.NonSignersPubkeysG1),
-					QuorumApks:                   utils.ToBN254G1Points(resp.QuorumApksG1),
-					ApkG2:                        utils.ToBN254G2Point(resp.SignersApkG2),
-					Sigma:                        utils.ToBN254G1Point(resp.SignersAggSigG1.G1Point),
-					QuorumApkIndices:             resp.QuorumApkIndices,
-					TotalStakeIndices:            resp.TotalStakeIndices,
-					NonSignerStakeIndices:        resp.NonSignerStakeIndices,
-				},
-			)
+			params := minimalCertificateVerifier.IBLSSignatureCheckerNonSignerStakesAndSignature{
+				NonSignerQuorumBitmapIndices: resp.NonSignerQuorumBitmapIndices,
+				NonSignerPubkeys:             utils.ToBN254G1Points(resp.NonSignersPubkeysG1),
+				QuorumApks:                   utils.ToBN254G1Points(resp.QuorumApksG1),
+				ApkG2:                        utils.ToBN254G2Point(resp.SignersApkG2),
+				Sigma:                        utils.ToBN254G1Point(resp.SignersAggSigG1.G1Point),
+				QuorumApkIndices:             resp.QuorumApkIndices,
+				TotalStakeIndices:            resp.TotalStakeIndices,
+				NonSignerStakeIndices:        resp.NonSignerStakeIndices,
+			}
+			var tx *gethtypes.Transaction
+			if !legacyDigest {
+				tx, err = certVerifier.VerifyCertificateV1(
+					txOpts,
+					uint32(task.TaskIndex),
+					resp.TaskResponse.([]byte),
+					[]byte{byte(quorumNumber)},
+					uint32(referenceBlockNumber),
+					params,
+				)
+			} else {
+				tx, err = certVerifier.VerifyCertificate(
+					txOpts,
+					resp.TaskResponse.([]byte),
+					[]byte{byte(quorumNumber)},
+					uint32(referenceBlockNumber),
+					params,
+				)
+			}
 			if err != nil {
 				logger.Error("Failed to assemble verify certificate tx", "error", err)
 				return
@@ -248,7 +372,7 @@ func start(c *cli.Context) error {
 				return
 			}
 
-			logger.Info("Sent verify certificate tx", "tx", receipt.TxHash.Hex(), "requestNumber", requestNumber)
+			logger.Info("Sent verify certificate tx", "tx", receipt.TxHash.Hex(), "taskIndex", task.TaskIndex)
 		}()
 	}
 }
@@ -10,6 +10,10 @@ contract MinimalCertificateVerifier is BLSSignatureChecker {
     uint256 public constant DENOMINATOR = 1e18;
     uint256 public constant THRESHOLD = DENOMINATOR / 2;
 
+    // Tags v1 digests, which bind a response to the chain, this verifier, the task and the
+    // reference block. Must match TaskResponseV1Tag of the teal common package.
+    bytes32 public constant TASK_RESPONSE_V1_TAG = keccak256("teal.TaskResponse.v1");
+
     // STORAGE
     struct VerificationRecord { 
         bytes quorumNumbers;
@@ -26,13 +30,55 @@ contract MinimalCertificateVerifier is BLSSignatureChecker {
         BLSSignatureChecker(__registryCoordinator)
     { }
 
+    // Verifies a certificate over the legacy digest keccak256(response), which is
+    // not bound to a deployment or task
     function verifyCertificate(
         bytes calldata response,
         bytes calldata quorumNumbers,
         uint32 referenceBlockNumber, 
         NonSignerStakesAndSignature calldata params
     ) external {
-        bytes32 responseHash = keccak256(response);
+        _verifyCertificate(keccak256(response), quorumNumbers, referenceBlockNumber, params);
+    }
+
+    // Verifies a certificate over the v1 digest of response, see taskResponseDigestV1
+    function verifyCertificateV1(
+        uint32 taskIndex,
+        bytes calldata response,
+        bytes calldata quorumNumbers,
+        uint32 referenceBlockNumber,
+        NonSignerStakesAndSignature calldata params
+    ) external {
+        _verifyCertificate(
+            taskResponseDigestV1(taskIndex, referenceBlockNumber, response),
+            quorumNumbers,
+            referenceBlockNumber,
+            params
+        );
+    }
+
+    // Returns the digest operators sign for response to a task, when signing v1 digests
+    function taskResponseDigestV1(
+        uint32 taskIndex,
+        uint32 referenceBlockNumber,
+        bytes calldata response
+    ) public view returns (bytes32) {
+        return keccak256(abi.encodePacked(
+            TASK_RESPONSE_V1_TAG,
+            block.chainid,
+            address(this),
+            taskIndex,
+            referenceBlockNumber,
+            keccak256(response)
+        ));
+    }
+
+    function _verifyCertificate(
+        bytes32 responseHash,
+        bytes calldata quorumNumbers,
+        uint32 referenceBlockNumber, 
+        NonSignerStakesAndSignature calldata params
+    ) internal {
         require(
             verificationRecords[responseHash].referenceBlockNumber == 0,
             "Certificate already verified"
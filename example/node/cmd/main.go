@@ -1,12 +1,23 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"math/big"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/Layr-Labs/teal/common"
 	"github.com/Layr-Labs/teal/example/node"
 	"github.com/Layr-Labs/teal/example/utils"
+	"github.com/Layr-Labs/teal/node/protection"
 	"github.com/Layr-Labs/teal/node/server"
+	"github.com/Layr-Labs/teal/node/service"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
 )
 
@@ -16,12 +27,50 @@ var (
 		Usage: "The port to serve the service on",
 		Value: 8080,
 	}
+	HttpPortFlag = cli.IntFlag{
+		Name:  "http-port",
+		Usage: "The port to serve the HTTP/JSON gateway and its swagger document on, disabled if 0",
+		Value: 0,
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:  "metrics-port",
+		Usage: "The port to serve Prometheus metrics on, disabled if 0",
+		Value: 9090,
+	}
 	BlsPrivateKeyFlag = cli.StringFlag{
 		Name:     "bls-private-key",
 		Usage:    "The private key to use for the node",
 		Value:    "",
 		Required: true,
 	}
+	AggregatorAddressFlag = cli.StringSliceFlag{
+		Name:  "aggregator-address",
+		Usage: "Only serve requests signed by these aggregator addresses, requests are not authenticated if unset",
+	}
+	SlashingProtectionDBFlag = cli.StringFlag{
+		Name:  "slashing-protection-db",
+		Usage: "The file the node records the responses it signed in",
+		Value: "slashing_protection.jsonl",
+	}
+	InterchangeFileFlag = cli.StringFlag{
+		Name:     "file",
+		Usage:    "The slashing protection interchange file",
+		Required: true,
+	}
+	SigningDomainChainIdFlag = cli.Uint64Flag{
+		Name:  "signing-domain-chain-id",
+		Usage: "The chain of the signing domain verifier, the chain of the eth url if 0",
+		Value: 0,
+	}
+	ShutdownTimeoutFlag = cli.DurationFlag{
+		Name:  "shutdown-timeout",
+		Usage: "How long to wait for in-flight requests on SIGINT or SIGTERM before canceling them",
+		Value: 30 * time.Second,
+	}
+	TLSRequireClientCertFlag = cli.BoolFlag{
+		Name:  "tls-require-client-cert",
+		Usage: "Reject clients without a certificate signed by the CA file",
+	}
 )
 
 func main() {
@@ -30,13 +79,52 @@ func main() {
 	app.Usage = "xyz"
 	app.Version = "0.0.1"
 
+	// the slashing protection commands don't use the chain, start checks the eth url itself
+	ethUrlFlag := utils.EthUrlFlag
+	ethUrlFlag.Required = false
+
 	app.Flags = []cli.Flag{
-		&utils.EthUrlFlag,
+		&ethUrlFlag,
 		&ServicePortFlag,
+		&HttpPortFlag,
+		&MetricsPortFlag,
 		&BlsPrivateKeyFlag,
+		&utils.TLSCertFileFlag,
+		&utils.TLSKeyFileFlag,
+		&utils.TLSCAFileFlag,
+		&TLSRequireClientCertFlag,
+		&AggregatorAddressFlag,
+		&SlashingProtectionDBFlag,
+		&utils.SigningDomainVerifierFlag,
+		&SigningDomainChainIdFlag,
+		&utils.LegacyDigestFlag,
+		&ShutdownTimeoutFlag,
+		&utils.TracingExporterFlag,
+		&utils.TracingFileFlag,
+		&utils.TracingOtlpEndpointFlag,
 	}
 
 	app.Action = start
+	app.Commands = []*cli.Command{
+		{
+			Name:  "slashing-protection",
+			Usage: "Move the slashing protection records between nodes",
+			Subcommands: []*cli.Command{
+				{
+					Name:   "export",
+					Usage:  "Export the slashing protection records to an interchange file",
+					Flags:  []cli.Flag{&InterchangeFileFlag},
+					Action: exportSlashingProtection,
+				},
+				{
+					Name:   "import",
+					Usage:  "Import the slashing protection records of an interchange file",
+					Flags:  []cli.Flag{&InterchangeFileFlag},
+					Action: importSlashingProtection,
+				},
+			},
+		},
+	}
 
 	if err := app.Run(os.Args); err != nil {
 		log.Fatal(err)
@@ -44,16 +132,127 @@ func main() {
 }
 
 func start(c *cli.Context) error {
+	if c.String(utils.EthUrlFlag.Name) == "" {
+		return fmt.Errorf("required flag %q not set", utils.EthUrlFlag.Name)
+	}
 	keyPair := utils.NewBlsKeyPairPanics(c.String(BlsPrivateKeyFlag.Name))
 
+	shutdownTracing, err := utils.SetupTracing(c, "teal-node")
+	if err != nil {
+		log.Fatal(err)
+	}
+	// flush the spans of the requests drained on shutdown
+	defer shutdownTracing(context.Background())
+
 	cfg := server.Config{
 		ServicePort: c.Int(ServicePortFlag.Name),
 		BlsKeyPair:  keyPair,
+		HttpPort:    c.Int(HttpPortFlag.Name),
+		MetricsPort: c.Int(MetricsPortFlag.Name),
+		TLS: common.TLSConfig{
+			CertFile:          c.String(utils.TLSCertFileFlag.Name),
+			KeyFile:           c.String(utils.TLSKeyFileFlag.Name),
+			CAFile:            c.String(utils.TLSCAFileFlag.Name),
+			RequireClientCert: c.Bool(TLSRequireClientCertFlag.Name),
+		},
 	}
 
-	node := node.NewUvnCallNode(cfg, c.String(utils.EthUrlFlag.Name))
-	if err := node.Start(); err != nil {
+	if aggregatorAddresses := c.StringSlice(AggregatorAddressFlag.Name); len(aggregatorAddresses) > 0 {
+		aggregators := make([]gethcommon.Address, len(aggregatorAddresses))
+		for i, address := range aggregatorAddresses {
+			if !gethcommon.IsHexAddress(address) {
+				log.Fatalf("invalid aggregator address %q", address)
+			}
+			aggregators[i] = gethcommon.HexToAddress(address)
+		}
+		cfg.AggregatorAllowlist = service.NewStaticAllowlist(aggregators...)
+	}
+
+	if c.Bool(utils.LegacyDigestFlag.Name) {
+		cfg.LegacyDigest = true
+	} else {
+		chainId := new(big.Int).SetUint64(c.Uint64(SigningDomainChainIdFlag.Name))
+		if chainId.Sign() == 0 {
+			client, err := ethclient.Dial(c.String(utils.EthUrlFlag.Name))
+			if err != nil {
+				log.Fatal(err)
+			}
+			chainId, err = client.ChainID(c.Context)
+			client.Close()
+			if err != nil {
+				log.Fatal(err)
+			}
+		}
+		cfg.SigningDomain, err = utils.SigningDomain(c, chainId, gethcommon.Address{})
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	slashingProtection, err := protection.NewFileDatabase(c.String(SlashingProtectionDBFlag.Name))
+	if err != nil {
 		log.Fatal(err)
 	}
-	return nil
+	defer slashingProtection.Close()
+	cfg.SlashingProtection = slashingProtection
+
+	node := node.NewUvnCallNode(cfg, c.String(utils.EthUrlFlag.Name))
+
+	signalCtx, stop := signal.NotifyContext(c.Context, syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	errC := make(chan error, 1)
+	go func() {
+		errC <- node.Start(context.Background())
+	}()
+
+	select {
+	case err := <-errC:
+		return err
+	case <-signalCtx.Done():
+	}
+
+	log.Printf("Shutting down, draining in-flight requests")
+	// a second signal cancels the in-flight requests right away
+	stop()
+	forceCtx, cancelForce := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancelForce()
+	shutdownCtx, cancel := context.WithTimeout(forceCtx, c.Duration(ShutdownTimeoutFlag.Name))
+	defer cancel()
+	if err := node.Stop(shutdownCtx); err != nil {
+		log.Printf("Canceled in-flight requests: %v", err)
+	}
+	return <-errC
+}
+
+func exportSlashingProtection(c *cli.Context) error {
+	keyPair := utils.NewBlsKeyPairPanics(c.String(BlsPrivateKeyFlag.Name))
+	db, err := protection.NewFileDatabase(c.String(SlashingProtectionDBFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	file, err := os.Create(c.String(InterchangeFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return protection.Export(db, keyPair.GetPubKeyG1().Serialize(), file)
+}
+
+func importSlashingProtection(c *cli.Context) error {
+	keyPair := utils.NewBlsKeyPairPanics(c.String(BlsPrivateKeyFlag.Name))
+	db, err := protection.NewFileDatabase(c.String(SlashingProtectionDBFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	file, err := os.Open(c.String(InterchangeFileFlag.Name))
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return protection.Import(db, keyPair.GetPubKeyG1().Serialize(), file)
 }
@@ -2,13 +2,16 @@ package node
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/Layr-Labs/teal/example/utils"
 	"github.com/Layr-Labs/teal/node/server"
+	"github.com/Layr-Labs/teal/node/service"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 const (
@@ -25,7 +28,7 @@ type EthCallNode struct {
 
 func NewEthCallNode(nodeConfig server.Config, rpcUrl string) *EthCallNode {
 	node := &EthCallNode{}
-	node.BaseNode = server.NewBaseNode(nodeConfig, node)
+	node.BaseNode = server.NewContextBaseNode(nodeConfig, node)
 
 	ethClient, err := ethclient.Dial(rpcUrl)
 	if err != nil {
@@ -36,32 +39,38 @@ func NewEthCallNode(nodeConfig server.Config, rpcUrl string) *EthCallNode {
 	return node
 }
 
-func (n *EthCallNode) GetResponse(nodeConfig server.Config, data []byte) ([]byte, error) {
+func (n *EthCallNode) GetResponseContext(ctx context.Context, nodeConfig server.Config, req server.CertifyRequest) ([]byte, error) {
+	data := req.Data
 	if len(data) < utils.MinDataSize {
-		return nil, fmt.Errorf("data too short")
+		return nil, fmt.Errorf("%w: data too short", service.ErrInvalidInput)
 	}
 
 	if len(data) > MaxDataSize {
-		return nil, fmt.Errorf("data too long")
+		return nil, fmt.Errorf("%w: data too long", service.ErrInvalidInput)
 	}
 
-	currBlockNumber, err := n.ethClient.BlockNumber(context.Background())
+	currBlockNumber, err := n.ethClient.BlockNumber(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current block number: %w", err)
+		return nil, fmt.Errorf("%w: failed to get current block number: %w", service.ErrUnavailable, err)
 	}
 
 	// next bytes are the call msg
 	blockNumber, callMsg := utils.CallFromBytes(data)
 	if blockNumber+MinBlockDepth > currBlockNumber || blockNumber+MaxBlockDepth < currBlockNumber {
-		return nil, fmt.Errorf("block number out of range")
+		return nil, fmt.Errorf("%w: block number out of range", service.ErrInvalidInput)
 	}
 	if callMsg.Gas > MaxGas {
-		return nil, fmt.Errorf("gas too high")
+		return nil, fmt.Errorf("%w: gas too high", service.ErrInvalidInput)
 	}
 
-	returnData, err := n.ethClient.CallContract(context.Background(), callMsg, big.NewInt(int64(blockNumber)))
+	returnData, err := n.ethClient.CallContract(ctx, callMsg, big.NewInt(int64(blockNumber)))
 	if err != nil {
-		return nil, fmt.Errorf("failed to call contract: %w", err)
+		// errors returned by the endpoint, like reverts, are caused by the call itself
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return nil, fmt.Errorf("%w: failed to call contract: %w", service.ErrInvalidInput, err)
+		}
+		return nil, fmt.Errorf("%w: failed to call contract: %w", service.ErrUnavailable, err)
 	}
 
 	// summarise request and return data and return response!
@@ -69,3 +78,16 @@ func (n *EthCallNode) GetResponse(nodeConfig server.Config, data []byte) ([]byte
 	returnDataHash := crypto.Keccak256(returnData)
 	return crypto.Keccak256(append(requestDataHash, returnDataHash...)), nil
 }
+
+// CheckHealth implements server.HealthChecker by checking that the RPC endpoint answers
+func (n *EthCallNode) CheckHealth(ctx context.Context) error {
+	if _, err := n.ethClient.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("rpc endpoint is unreachable: %w", err)
+	}
+	return nil
+}
+
+// TaskTypes implements server.TaskTypesCertifier
+func (n *EthCallNode) TaskTypes() []string {
+	return []string{"eth_call"}
+}
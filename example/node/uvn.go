@@ -3,16 +3,19 @@ package node
 import (
 	"context"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/Layr-Labs/teal/node/server"
+	"github.com/Layr-Labs/teal/node/service"
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/ethclient"
 )
 
 const (
-	BnSize        = 8
+	BnSize = 8
 )
 
 type UvnCallNode struct {
@@ -22,7 +25,7 @@ type UvnCallNode struct {
 
 func NewUvnCallNode(nodeConfig server.Config, rpcUrl string) *UvnCallNode {
 	node := &UvnCallNode{}
-	node.BaseNode = server.NewBaseNode(nodeConfig, node)
+	node.BaseNode = server.NewContextBaseNode(nodeConfig, node)
 
 	uniClient, err := ethclient.Dial(rpcUrl)
 	if err != nil {
@@ -33,19 +36,36 @@ func NewUvnCallNode(nodeConfig server.Config, rpcUrl string) *UvnCallNode {
 	return node
 }
 
-func (n *UvnCallNode) GetResponse(nodeConfig server.Config, data []byte) ([]byte, error) {
+func (n *UvnCallNode) GetResponseContext(ctx context.Context, nodeConfig server.Config, req server.CertifyRequest) ([]byte, error) {
+	data := req.Data
 	if len(data) < BnSize {
-		return nil, fmt.Errorf("data too short")
+		return nil, fmt.Errorf("%w: data too short", service.ErrInvalidInput)
 	}
 
 	bn := binary.BigEndian.Uint64(data[:BnSize])
-	
-	block, err := n.uniClient.BlockByNumber(context.Background(), big.NewInt(int64(bn)))
+
+	block, err := n.uniClient.BlockByNumber(ctx, big.NewInt(int64(bn)))
 	if err != nil {
-		return nil, fmt.Errorf("failed to get block by number: %w", err)
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, fmt.Errorf("%w: block %d not found", service.ErrInvalidInput, bn)
+		}
+		return nil, fmt.Errorf("%w: failed to get block by number: %w", service.ErrUnavailable, err)
 	}
 
 	blockHash := block.Hash().Bytes()
 
 	return crypto.Keccak256(append(data, blockHash...)), nil
 }
+
+// CheckHealth implements server.HealthChecker by checking that the RPC endpoint answers
+func (n *UvnCallNode) CheckHealth(ctx context.Context) error {
+	if _, err := n.uniClient.BlockNumber(ctx); err != nil {
+		return fmt.Errorf("rpc endpoint is unreachable: %w", err)
+	}
+	return nil
+}
+
+// TaskTypes implements server.TaskTypesCertifier
+func (n *UvnCallNode) TaskTypes() []string {
+	return []string{"uvn"}
+}
@@ -4,6 +4,7 @@ set -e
 
 # Default values
 RPC_URL="http://0.0.0.0:8545"
+ETH_RPC_URL=""
 
 
 # Parse named arguments
@@ -13,8 +14,12 @@ while [ $# -gt 0 ]; do
       RPC_URL="$2"
       shift 2
       ;;
+    --eth-rpc-url)
+      ETH_RPC_URL="$2"
+      shift 2
+      ;;
     --help)
-      echo "Usage: $0 --rpc-url <rpc-url>"
+      echo "Usage: $0 --rpc-url <rpc-url> --eth-rpc-url <eth-rpc-url>"
       exit 0
       ;;
     *)
@@ -24,6 +29,11 @@ while [ $# -gt 0 ]; do
   esac
 done
 
+if [ -z "$ETH_RPC_URL" ]; then
+  echo "Error: --eth-rpc-url is required"
+  exit 1
+fi
+
 declare -a PIDS=()
 
 # Get the directory where the script is located
@@ -33,6 +43,11 @@ SCRIPT_DIR="$( cd "$( dirname "${BASH_SOURCE[0]}" )" && pwd )"
 PARENT_DIR=$SCRIPT_DIR/..
 go build -o $PARENT_DIR/bin/node $PARENT_DIR/node/cmd/main.go
 
+# The nodes sign digests bound to the certificate verifier and the chain it is deployed on
+VERIFIER=$(jq -r '.certificateVerifier' $PARENT_DIR/contracts/script/output/avs_deploy_output.json)
+CHAIN_ID=$(printf "%d" $(curl -s -X POST -H "Content-Type: application/json" \
+  --data '{"jsonrpc":"2.0","method":"eth_chainId","params":[],"id":1}' $ETH_RPC_URL | jq -r '.result'))
+
 for file in $SCRIPT_DIR/operators/*.json; do
   echo "Starting node from $file"
   if [ -r "$file" ]; then
@@ -41,7 +56,8 @@ for file in $SCRIPT_DIR/operators/*.json; do
     SOCKET=$(jq -r '.socket' $file)
     PORT=$(echo $SOCKET | cut -d ':' -f 2)
     echo "Starting node with BLS private key $BLS_PRIVATE_KEY, ECDSA private key $ECDSA_PRIVATE_KEY, socket $SOCKET, and port $PORT"
-    $PARENT_DIR/bin/node --bls-private-key $BLS_PRIVATE_KEY --service-port $PORT --eth-url $RPC_URL & PIDS+=($!)
+    $PARENT_DIR/bin/node --bls-private-key $BLS_PRIVATE_KEY --service-port $PORT --eth-url $RPC_URL \
+      --signing-domain-verifier $VERIFIER --signing-domain-chain-id $CHAIN_ID & PIDS+=($!)
   else
     echo "File $file is not readable"
   fi
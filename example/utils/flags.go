@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"github.com/Layr-Labs/teal/common"
 	"github.com/urfave/cli/v2"
 )
 
@@ -29,10 +30,70 @@ var (
 		Value:    "",
 		Required: true,
 	}
+	DataDirFlag = cli.StringFlag{
+		Name:  "data-dir",
+		Usage: "The directory the aggregator persists its tasks and certificates in",
+		Value: "data",
+	}
+	MetricsPortFlag = cli.IntFlag{
+		Name:  "metrics-port",
+		Usage: "The port the aggregator serves Prometheus metrics on, disabled if 0",
+		Value: 9091,
+	}
+	AggregatorServicePortFlag = cli.IntFlag{
+		Name:  "aggregator-service-port",
+		Usage: "The port the aggregator serves its gRPC API for submitting tasks on, disabled if 0",
+		Value: 0,
+	}
+	AggregatorHttpPortFlag = cli.IntFlag{
+		Name:  "aggregator-http-port",
+		Usage: "The port the aggregator serves the HTTP/JSON gateway of its API on, disabled if 0",
+		Value: 0,
+	}
+	TracingExporterFlag = cli.StringFlag{
+		Name:  "tracing-exporter",
+		Usage: "Where spans are exported to: none, stdout, file or otlp",
+		Value: common.TracingExporterNone,
+	}
+	TracingFileFlag = cli.StringFlag{
+		Name:  "tracing-file",
+		Usage: "The file the file tracing exporter appends spans to",
+		Value: "traces.jsonl",
+	}
+	TracingOtlpEndpointFlag = cli.StringFlag{
+		Name:  "tracing-otlp-endpoint",
+		Usage: "The URL of the OTLP/gRPC collector of the otlp tracing exporter, e.g. http://localhost:4317",
+		Value: "",
+	}
 	UnichainUrlFlag = cli.StringFlag{
 		Name:     "unichain-url",
 		Usage:    "The URL of the unichain node",
 		Value:    "",
 		Required: true,
 	}
+	TLSCertFileFlag = cli.StringFlag{
+		Name:  "tls-cert-file",
+		Usage: "The certificate presented to peers, enables TLS on the node and mutual TLS on the aggregator",
+		Value: "",
+	}
+	TLSKeyFileFlag = cli.StringFlag{
+		Name:  "tls-key-file",
+		Usage: "The key of the certificate presented to peers",
+		Value: "",
+	}
+	TLSCAFileFlag = cli.StringFlag{
+		Name:  "tls-ca-file",
+		Usage: "The CA certificates peers are verified against, enables TLS on the aggregator",
+		Value: "",
+	}
+	SigningDomainVerifierFlag = cli.StringFlag{
+		Name:  "signing-domain-verifier",
+		Usage: "The certificate verifier signed digests are bound to, together with the chain of the eth url. The nodes and the aggregator must use the same one, aggregators default to the verifier of their deployment",
+		Value: "",
+	}
+	LegacyDigestFlag = cli.BoolFlag{
+		Name:  "legacy-digest",
+		Usage: "Sign keccak256(response) instead of a digest bound to the signing domain, such signatures can be replayed on other chains and verifiers",
+		Value: false,
+	}
 )
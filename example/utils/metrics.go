@@ -0,0 +1,16 @@
+package utils
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// ServeMetrics serves the metrics of reg on /metrics of port until the process exits
+func ServeMetrics(port int, reg *prometheus.Registry) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", promhttp.HandlerFor(reg, promhttp.HandlerOpts{Registry: reg}))
+	return http.ListenAndServe(fmt.Sprintf(":%d", port), mux)
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/teal/common"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+)
+
+// SigningDomain returns the signing domain of the signing domain verifier flag, or of
+// defaultVerifier if the flag is not set, on the chain with chainId
+func SigningDomain(c *cli.Context, chainId *big.Int, defaultVerifier gethcommon.Address) (common.SigningDomain, error) {
+	verifier := c.String(SigningDomainVerifierFlag.Name)
+	if verifier == "" {
+		if defaultVerifier == (gethcommon.Address{}) {
+			return common.SigningDomain{}, fmt.Errorf("flag %q is required unless %q is set", SigningDomainVerifierFlag.Name, LegacyDigestFlag.Name)
+		}
+		return common.SigningDomain{ChainId: chainId, VerifierAddress: defaultVerifier}, nil
+	}
+	if !gethcommon.IsHexAddress(verifier) {
+		return common.SigningDomain{}, fmt.Errorf("invalid certificate verifier address %q", verifier)
+	}
+	return common.SigningDomain{
+		ChainId:         chainId,
+		VerifierAddress: gethcommon.HexToAddress(verifier),
+	}, nil
+}
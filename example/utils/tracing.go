@@ -0,0 +1,19 @@
+package utils
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/teal/common"
+	"github.com/urfave/cli/v2"
+)
+
+// SetupTracing installs the global tracer provider selected by the tracing flags, the
+// returned function flushes the spans that were not exported yet
+func SetupTracing(c *cli.Context, serviceName string) (func(context.Context) error, error) {
+	return common.SetupTracing(c.Context, common.TracingConfig{
+		Exporter:     c.String(TracingExporterFlag.Name),
+		File:         c.String(TracingFileFlag.Name),
+		OtlpEndpoint: c.String(TracingOtlpEndpointFlag.Name),
+		ServiceName:  serviceName,
+	})
+}
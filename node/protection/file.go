@@ -0,0 +1,189 @@
+package protection
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// FileDatabase is an append-only journal of JSON lines, one per signed response.
+// Every record is synced to disk before the response is signed.
+type FileDatabase struct {
+	file *os.File
+	// index.mu also guards writes to file
+	index *InMemoryDatabase
+}
+
+var _ Database = (*FileDatabase)(nil)
+
+// NewFileDatabase opens the journal at path, creating it if needed. A last record that
+// can't be decoded was cut off by a crash while it was written, it is dropped with a warning.
+// The response of such a record was never signed, as records are synced before signing.
+func NewFileDatabase(path string) (*FileDatabase, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create slashing protection directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open slashing protection database: %w", err)
+	}
+
+	d := &FileDatabase{
+		file:  file,
+		index: NewInMemoryDatabase(),
+	}
+
+	if err := d.replay(); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// replay restores the records of the journal
+func (d *FileDatabase) replay() error {
+	reader := bufio.NewReader(d.file)
+	var offset int64
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("failed to read slashing protection database: %w", err)
+		}
+		last := errors.Is(err, io.EOF)
+		if len(bytes.TrimSpace(line)) == 0 {
+			if last {
+				return nil
+			}
+			offset += int64(len(line))
+			continue
+		}
+
+		var stored storedRecord
+		if err := json.Unmarshal(line, &stored); err != nil {
+			if _, peekErr := reader.Peek(1); !last && !errors.Is(peekErr, io.EOF) {
+				return fmt.Errorf("failed to decode slashing protection record: %w", err)
+			}
+			// appending after the partial record would corrupt the next one as well
+			log.Printf("Dropping a partially written slashing protection record of %s at offset %d: %v", d.file.Name(), offset, err)
+			if err := d.file.Truncate(offset); err != nil {
+				return fmt.Errorf("failed to truncate slashing protection database: %w", err)
+			}
+			return nil
+		}
+		record, err := stored.toRecord()
+		if err != nil {
+			return err
+		}
+		// a conflict can only come from tampering with the journal, refuse to start
+		if _, err := d.index.check(record); err != nil {
+			return fmt.Errorf("slashing protection database is inconsistent: %w", err)
+		}
+
+		if last {
+			// terminate the record, so that the next one starts on a line of its own
+			if _, err := d.file.Write([]byte{'\n'}); err != nil {
+				return fmt.Errorf("failed to write slashing protection database: %w", err)
+			}
+			return nil
+		}
+		offset += int64(len(line))
+	}
+}
+
+func (d *FileDatabase) CheckAndRecord(aggregator gethcommon.Address, taskIndex uint32, digest gethcommon.Hash) error {
+	d.index.mu.Lock()
+	defer d.index.mu.Unlock()
+
+	record := Record{Aggregator: aggregator, TaskIndex: taskIndex, ResponseDigest: digest, SignedAt: time.Now()}
+	isNew, err := d.index.check(record)
+	if err != nil || !isNew {
+		return err
+	}
+	if err := d.append(record); err != nil {
+		// the response must not be signed if it was not recorded
+		delete(d.index.records, record.key())
+		return err
+	}
+	return nil
+}
+
+func (d *FileDatabase) Records() ([]Record, error) {
+	return d.index.Records()
+}
+
+func (d *FileDatabase) Import(records []Record) error {
+	d.index.mu.Lock()
+	defer d.index.mu.Unlock()
+
+	added, err := d.index.importRecords(records)
+	if err != nil {
+		return err
+	}
+	for i, record := range added {
+		if err := d.append(record); err != nil {
+			// the records written so far stay, they are replayed on the next start anyway
+			for _, unwritten := range added[i:] {
+				delete(d.index.records, unwritten.key())
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *FileDatabase) Close() error {
+	return d.file.Close()
+}
+
+// append must be called with d.index.mu held
+func (d *FileDatabase) append(record Record) error {
+	raw, err := json.Marshal(newStoredRecord(record))
+	if err != nil {
+		return fmt.Errorf("failed to encode slashing protection record: %w", err)
+	}
+	if _, err := d.file.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("failed to write slashing protection record: %w", err)
+	}
+	if err := d.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync slashing protection database: %w", err)
+	}
+	return nil
+}
+
+// storedRecord is the on-disk representation of a Record
+type storedRecord struct {
+	Aggregator     *gethcommon.Address `json:"aggregator"`
+	TaskIndex      uint32              `json:"task_index"`
+	ResponseDigest gethcommon.Hash     `json:"response_digest"`
+	SignedAt       time.Time           `json:"signed_at"`
+}
+
+func newStoredRecord(r Record) *storedRecord {
+	return &storedRecord{
+		Aggregator:     &r.Aggregator,
+		TaskIndex:      r.TaskIndex,
+		ResponseDigest: r.ResponseDigest,
+		SignedAt:       r.SignedAt,
+	}
+}
+
+func (s *storedRecord) toRecord() (Record, error) {
+	if s.Aggregator == nil {
+		return Record{}, fmt.Errorf("slashing protection record of task %d has no aggregator", s.TaskIndex)
+	}
+	return Record{
+		Aggregator:     *s.Aggregator,
+		TaskIndex:      s.TaskIndex,
+		ResponseDigest: s.ResponseDigest,
+		SignedAt:       s.SignedAt,
+	}, nil
+}
@@ -0,0 +1,260 @@
+// Package protection keeps a record of the responses a node signed, so that it never signs
+// two different responses for the same task index of an aggregator. It is the equivalent
+// of validator slashing protection.
+//
+// Task indices are allocated by each aggregator, so records are kept per aggregator
+// address. An aggregator must persist its task indices, as the file task registry does,
+// or nodes refuse the tasks whose indices it reuses after a restart.
+//
+// Records can be moved between nodes in the following JSON interchange format, modeled
+// after EIP-3076:
+//
+//	{
+//	  "metadata": {
+//	    "interchange_format_version": "1",
+//	    "signer_public_key": "0x..."
+//	  },
+//	  "data": [
+//	    {
+//	      "aggregator": "0x...",
+//	      "task_index": "12",
+//	      "response_digest": "0x...",
+//	      "signed_at": "2024-01-01T00:00:00Z"
+//	    }
+//	  ]
+//	}
+//
+// interchange_format_version is always "1". signer_public_key is the optional hex encoded
+// BLS G1 public key of the operator the records belong to. aggregator is the optional
+// address of the aggregator that requested the signature, the zero address if it is
+// omitted, and task_index is a decimal string,
+// response_digest the hex encoded 32 byte digest that was signed and signed_at an optional
+// RFC 3339 timestamp. Importing fails without changing the database if any record conflicts
+// with an existing record or another imported record.
+package protection
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// InterchangeFormatVersion is the version of the interchange format written by Export
+const InterchangeFormatVersion = "1"
+
+// ErrConflictingSignature is returned when a different response was already signed for a task index
+var ErrConflictingSignature = errors.New("a different response was already signed for this task")
+
+// Record is a response digest signed for a task index of an aggregator
+type Record struct {
+	// Aggregator is the address of the aggregator that requested the signature, the zero
+	// address if requests are not authenticated
+	Aggregator     gethcommon.Address
+	TaskIndex      uint32
+	ResponseDigest gethcommon.Hash
+	SignedAt       time.Time
+}
+
+// Database records signed responses
+type Database interface {
+	// CheckAndRecord records that digest is about to be signed for taskIndex of aggregator.
+	// It returns ErrConflictingSignature if another digest was signed for it. Signing the
+	// same digest again is allowed.
+	CheckAndRecord(aggregator gethcommon.Address, taskIndex uint32, digest gethcommon.Hash) error
+	// Records returns all records ordered by aggregator and task index
+	Records() ([]Record, error)
+	// Import adds records, failing without changes if any of them conflicts
+	Import(records []Record) error
+	Close() error
+}
+
+// InMemoryDatabase keeps records in memory only, it is meant for tests
+type InMemoryDatabase struct {
+	mu      sync.Mutex
+	records map[recordKey]Record
+}
+
+// recordKey identifies the task a record was signed for
+type recordKey struct {
+	aggregator gethcommon.Address
+	taskIndex  uint32
+}
+
+func (r Record) key() recordKey {
+	return recordKey{aggregator: r.Aggregator, taskIndex: r.TaskIndex}
+}
+
+var _ Database = (*InMemoryDatabase)(nil)
+
+func NewInMemoryDatabase() *InMemoryDatabase {
+	return &InMemoryDatabase{records: make(map[recordKey]Record)}
+}
+
+func (d *InMemoryDatabase) CheckAndRecord(aggregator gethcommon.Address, taskIndex uint32, digest gethcommon.Hash) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, err := d.check(Record{Aggregator: aggregator, TaskIndex: taskIndex, ResponseDigest: digest, SignedAt: time.Now()})
+	return err
+}
+
+// check adds record unless it conflicts and reports whether it is new. d.mu must be held.
+func (d *InMemoryDatabase) check(record Record) (bool, error) {
+	existing, ok := d.records[record.key()]
+	if !ok {
+		d.records[record.key()] = record
+		return true, nil
+	}
+	if existing.ResponseDigest != record.ResponseDigest {
+		return false, fmt.Errorf("%w: task %d of aggregator %s, signed %s, requested %s",
+			ErrConflictingSignature, record.TaskIndex, record.Aggregator.Hex(), existing.ResponseDigest.Hex(), record.ResponseDigest.Hex())
+	}
+	return false, nil
+}
+
+func (d *InMemoryDatabase) Records() ([]Record, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	records := make([]Record, 0, len(d.records))
+	for _, record := range d.records {
+		records = append(records, record)
+	}
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Aggregator != records[j].Aggregator {
+			return bytes.Compare(records[i].Aggregator[:], records[j].Aggregator[:]) < 0
+		}
+		return records[i].TaskIndex < records[j].TaskIndex
+	})
+	return records, nil
+}
+
+func (d *InMemoryDatabase) Import(records []Record) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	_, err := d.importRecords(records)
+	return err
+}
+
+// importRecords adds records unless any of them conflicts and returns the ones that are new.
+// d.mu must be held.
+func (d *InMemoryDatabase) importRecords(records []Record) ([]Record, error) {
+	pending := make(map[recordKey]gethcommon.Hash, len(records))
+	for _, record := range records {
+		if existing, ok := d.records[record.key()]; ok && existing.ResponseDigest != record.ResponseDigest {
+			return nil, fmt.Errorf("%w: task %d of aggregator %s", ErrConflictingSignature, record.TaskIndex, record.Aggregator.Hex())
+		}
+		if digest, ok := pending[record.key()]; ok && digest != record.ResponseDigest {
+			return nil, fmt.Errorf("%w: task %d of aggregator %s is imported twice", ErrConflictingSignature, record.TaskIndex, record.Aggregator.Hex())
+		}
+		pending[record.key()] = record.ResponseDigest
+	}
+
+	var added []Record
+	for _, record := range records {
+		if isNew, _ := d.check(record); isNew {
+			added = append(added, record)
+		}
+	}
+	return added, nil
+}
+
+func (d *InMemoryDatabase) Close() error {
+	return nil
+}
+
+// Interchange is the document exchanged by Export and Import
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeRecord `json:"data"`
+}
+
+type InterchangeMetadata struct {
+	InterchangeFormatVersion string        `json:"interchange_format_version"`
+	SignerPublicKey          hexutil.Bytes `json:"signer_public_key,omitempty"`
+}
+
+type InterchangeRecord struct {
+	Aggregator     *gethcommon.Address `json:"aggregator,omitempty"`
+	TaskIndex      string              `json:"task_index"`
+	ResponseDigest gethcommon.Hash     `json:"response_digest"`
+	SignedAt       *time.Time          `json:"signed_at,omitempty"`
+}
+
+// Export writes all records of db for the operator with signerPublicKey, which may be nil, to w
+func Export(db Database, signerPublicKey []byte, w io.Writer) error {
+	records, err := db.Records()
+	if err != nil {
+		return err
+	}
+
+	interchange := Interchange{
+		Metadata: InterchangeMetadata{
+			InterchangeFormatVersion: InterchangeFormatVersion,
+			SignerPublicKey:          signerPublicKey,
+		},
+		Data: make([]InterchangeRecord, len(records)),
+	}
+	for i, record := range records {
+		interchange.Data[i] = InterchangeRecord{
+			TaskIndex:      strconv.FormatUint(uint64(record.TaskIndex), 10),
+			ResponseDigest: record.ResponseDigest,
+		}
+		if record.Aggregator != (gethcommon.Address{}) {
+			aggregator := record.Aggregator
+			interchange.Data[i].Aggregator = &aggregator
+		}
+		if !record.SignedAt.IsZero() {
+			signedAt := record.SignedAt.UTC()
+			interchange.Data[i].SignedAt = &signedAt
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(interchange)
+}
+
+// Import reads records from r into db. If signerPublicKey is set, the records must belong to it.
+func Import(db Database, signerPublicKey []byte, r io.Reader) error {
+	var interchange Interchange
+	if err := json.NewDecoder(r).Decode(&interchange); err != nil {
+		return fmt.Errorf("failed to decode interchange: %w", err)
+	}
+	if interchange.Metadata.InterchangeFormatVersion != InterchangeFormatVersion {
+		return fmt.Errorf("unsupported interchange format version %q", interchange.Metadata.InterchangeFormatVersion)
+	}
+	if len(signerPublicKey) > 0 && len(interchange.Metadata.SignerPublicKey) > 0 &&
+		hexutil.Encode(signerPublicKey) != hexutil.Encode(interchange.Metadata.SignerPublicKey) {
+		return fmt.Errorf("interchange belongs to signer %s", interchange.Metadata.SignerPublicKey)
+	}
+
+	records := make([]Record, len(interchange.Data))
+	for i, data := range interchange.Data {
+		taskIndex, err := strconv.ParseUint(data.TaskIndex, 10, 32)
+		if err != nil {
+			return fmt.Errorf("invalid task index %q: %w", data.TaskIndex, err)
+		}
+		records[i] = Record{
+			TaskIndex:      uint32(taskIndex),
+			ResponseDigest: data.ResponseDigest,
+		}
+		if data.Aggregator != nil {
+			records[i].Aggregator = *data.Aggregator
+		}
+		if data.SignedAt != nil {
+			records[i].SignedAt = *data.SignedAt
+		}
+	}
+	return db.Import(records)
+}
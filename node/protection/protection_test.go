@@ -0,0 +1,159 @@
+package protection_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Layr-Labs/teal/node/protection"
+)
+
+func TestDatabase(t *testing.T) {
+	aggregatorA := gethcommon.Address{0xa}
+	aggregatorB := gethcommon.Address{0xb}
+	digestA := gethcommon.Hash{0xa}
+	digestB := gethcommon.Hash{0xb}
+
+	databases := map[string]func(t *testing.T) protection.Database{
+		"in memory": func(t *testing.T) protection.Database {
+			return protection.NewInMemoryDatabase()
+		},
+		"file": func(t *testing.T) protection.Database {
+			db, err := protection.NewFileDatabase(filepath.Join(t.TempDir(), "protection.jsonl"))
+			require.NoError(t, err)
+			t.Cleanup(func() { db.Close() })
+			return db
+		},
+	}
+
+	for name, newDatabase := range databases {
+		t.Run(name, func(t *testing.T) {
+			t.Run("refuses conflicting signatures", func(t *testing.T) {
+				db := newDatabase(t)
+
+				assert.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+				assert.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+				assert.ErrorIs(t, db.CheckAndRecord(aggregatorA, 1, digestB), protection.ErrConflictingSignature)
+				assert.NoError(t, db.CheckAndRecord(aggregatorA, 2, digestB))
+			})
+
+			t.Run("keeps the task indices of aggregators apart", func(t *testing.T) {
+				db := newDatabase(t)
+
+				assert.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+				assert.NoError(t, db.CheckAndRecord(aggregatorB, 1, digestB))
+				assert.ErrorIs(t, db.CheckAndRecord(aggregatorB, 1, digestA), protection.ErrConflictingSignature)
+			})
+
+			t.Run("exports and imports records", func(t *testing.T) {
+				db := newDatabase(t)
+				require.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+				require.NoError(t, db.CheckAndRecord(aggregatorA, 2, digestB))
+				require.NoError(t, db.CheckAndRecord(aggregatorB, 1, digestB))
+
+				var interchange bytes.Buffer
+				require.NoError(t, protection.Export(db, []byte{1, 2, 3}, &interchange))
+
+				imported := newDatabase(t)
+				require.NoError(t, protection.Import(imported, []byte{1, 2, 3}, bytes.NewReader(interchange.Bytes())))
+				assert.ErrorIs(t, imported.CheckAndRecord(aggregatorA, 1, digestB), protection.ErrConflictingSignature)
+				assert.NoError(t, imported.CheckAndRecord(aggregatorA, 2, digestB))
+				assert.ErrorIs(t, imported.CheckAndRecord(aggregatorB, 1, digestA), protection.ErrConflictingSignature)
+
+				// records of another signer are refused
+				assert.Error(t, protection.Import(newDatabase(t), []byte{4, 5, 6}, bytes.NewReader(interchange.Bytes())))
+			})
+
+			t.Run("conflicting imports change nothing", func(t *testing.T) {
+				db := newDatabase(t)
+				require.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+
+				err := db.Import([]protection.Record{
+					{Aggregator: aggregatorA, TaskIndex: 2, ResponseDigest: digestA},
+					{Aggregator: aggregatorA, TaskIndex: 1, ResponseDigest: digestB},
+				})
+				assert.ErrorIs(t, err, protection.ErrConflictingSignature)
+
+				err = db.Import([]protection.Record{
+					{Aggregator: aggregatorA, TaskIndex: 3, ResponseDigest: digestA},
+					{Aggregator: aggregatorA, TaskIndex: 3, ResponseDigest: digestB},
+				})
+				assert.ErrorIs(t, err, protection.ErrConflictingSignature)
+
+				records, err := db.Records()
+				require.NoError(t, err)
+				assert.Len(t, records, 1)
+			})
+		})
+	}
+
+	t.Run("file database persists records", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "protection.jsonl")
+		db, err := protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		require.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+		require.NoError(t, db.Import([]protection.Record{{Aggregator: aggregatorA, TaskIndex: 2, ResponseDigest: digestB}}))
+		require.NoError(t, db.Close())
+
+		db, err = protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		defer db.Close()
+		assert.ErrorIs(t, db.CheckAndRecord(aggregatorA, 1, digestB), protection.ErrConflictingSignature)
+		assert.ErrorIs(t, db.CheckAndRecord(aggregatorA, 2, digestA), protection.ErrConflictingSignature)
+	})
+
+	t.Run("file database drops a partially written last record", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "protection.jsonl")
+		db, err := protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		require.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+		require.NoError(t, db.Close())
+		appendToFile(t, path, `{"aggregator":"`+aggregatorA.Hex()+`","task_index":2,"resp`)
+
+		db, err = protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		require.NoError(t, db.CheckAndRecord(aggregatorA, 2, digestB))
+		require.NoError(t, db.Close())
+
+		// the journal is readable again after records were appended to it
+		db, err = protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		defer db.Close()
+		assert.ErrorIs(t, db.CheckAndRecord(aggregatorA, 1, digestB), protection.ErrConflictingSignature)
+		assert.ErrorIs(t, db.CheckAndRecord(aggregatorA, 2, digestA), protection.ErrConflictingSignature)
+	})
+
+	t.Run("file database refuses a corrupted record before the last one", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "protection.jsonl")
+		db, err := protection.NewFileDatabase(path)
+		require.NoError(t, err)
+		require.NoError(t, db.CheckAndRecord(aggregatorA, 1, digestA))
+		require.NoError(t, db.Close())
+		appendToFile(t, path, "{\"task_index\":2,\n{}\n")
+
+		_, err = protection.NewFileDatabase(path)
+		assert.Error(t, err)
+	})
+
+	t.Run("file database refuses records without an aggregator", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "protection.jsonl")
+		line := `{"task_index":1,"response_digest":"` + digestA.Hex() + `","signed_at":"2024-01-01T00:00:00Z"}` + "\n"
+		require.NoError(t, os.WriteFile(path, []byte(line), 0o600))
+
+		_, err := protection.NewFileDatabase(path)
+		assert.Error(t, err)
+	})
+}
+
+func appendToFile(t *testing.T, path string, data string) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = file.WriteString(data)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
@@ -1,72 +1,598 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/reflection"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	v1 "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/api/swagger"
+	"github.com/Layr-Labs/teal/common"
+	"github.com/Layr-Labs/teal/node/protection"
 	"github.com/Layr-Labs/teal/node/service"
 )
 
 type Config struct {
 	ServicePort int
 	BlsKeyPair  *bls.KeyPair
+	// HttpPort serves the HTTP/JSON gateway and its swagger document, disabled if 0
+	HttpPort int
+	// TLS secures the service if TLS.CertFile is set
+	TLS common.TLSConfig
+	// AggregatorAllowlist restricts requests to the ones signed by its aggregators.
+	// Requests are not authenticated if it is nil.
+	AggregatorAllowlist service.AggregatorAllowlist
+	// SlashingProtection keeps the node from signing conflicting responses for a task if set
+	SlashingProtection protection.Database
+	// SigningDomain binds the digests the node signs to a deployment and task, it is
+	// required unless LegacyDigest is set
+	SigningDomain common.SigningDomain
+	// LegacyDigest signs keccak256(response) instead of the digests of SigningDomain,
+	// such signatures can be replayed on other chains and verifiers
+	LegacyDigest bool
+	// HealthCheckInterval is the interval the certifier's health check runs at if it
+	// implements HealthChecker, DefaultHealthCheckInterval if 0
+	HealthCheckInterval time.Duration
+	// MetricsPort serves Prometheus metrics at /metrics, disabled if 0
+	MetricsPort int
+	// MetricsRegistry is the registry the node registers its metrics with and serves.
+	// A registry with the Go runtime and process collectors is created if nil.
+	MetricsRegistry *prometheus.Registry
+	// TracerProvider records the spans of requests, which continue the trace propagated by
+	// the aggregator. The global tracer provider is used if nil.
+	TracerProvider trace.TracerProvider
 }
+
+// Certifier computes the response a node signs for the data of a request. Prefer
+// ContextCertifier, whose work is canceled with the request.
 type Certifier interface {
 	GetResponse(config Config, data []byte) ([]byte, error)
 }
 
+// CertifyRequest is a request of an aggregator to certify its data
+type CertifyRequest struct {
+	TaskIndex            uint32
+	ReferenceBlockNumber uint32
+	Data                 []byte
+	// Peer is the address the request was received from, nil if it is unknown
+	Peer net.Addr
+}
+
+// ContextCertifier computes the response a node signs for a request. ctx carries the
+// deadline of the aggregator and is canceled once it gives up on the request.
+type ContextCertifier interface {
+	GetResponseContext(ctx context.Context, config Config, req CertifyRequest) ([]byte, error)
+}
+
+// HealthChecker is implemented by certifiers that depend on external resources, like an
+// RPC endpoint. The node reports itself as not serving through the gRPC health service
+// while the check fails.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// TaskTypesCertifier is implemented by certifiers that advertise the task types they
+// certify through GetInfo
+type TaskTypesCertifier interface {
+	TaskTypes() []string
+}
+
+// DefaultHealthCheckInterval is the interval health checks run at if none is configured
+const DefaultHealthCheckInterval = 10 * time.Second
+
+// AdaptCertifier makes a Certifier usable as a ContextCertifier. The context and the
+// metadata of requests are ignored.
+func AdaptCertifier(certifier Certifier) ContextCertifier {
+	return certifierAdapter{certifier}
+}
+
+type certifierAdapter struct {
+	certifier Certifier
+}
+
+func (a certifierAdapter) GetResponseContext(_ context.Context, config Config, req CertifyRequest) ([]byte, error) {
+	return a.certifier.GetResponse(config, req.Data)
+}
+
+// ErrNodeRunning is returned when starting a node that is already running
+var ErrNodeRunning = errors.New("node is already running")
+
 type BaseNode struct {
 	config    Config
-	certifier Certifier
+	certifier ContextCertifier
+	// implementation is the certifier the node was created with, it may implement
+	// HealthChecker and TaskTypesCertifier
+	implementation any
+
+	metrics         *service.Metrics
+	metricsGatherer prometheus.Gatherer
+
+	mu  sync.Mutex
+	run *run
+	// ready is closed once the node listens, it is replaced when the node stops
+	ready chan struct{}
+}
+
+// run is the state of a node between its start and its stop
+type run struct {
+	grpcServer  *grpc.Server
+	httpServers []httpListener
+	health      *health.Server
+	addr        net.Addr
+	httpAddr    net.Addr
+	metricsAddr net.Addr
+	// stopHealthChecks ends the health checks of the run
+	stopHealthChecks context.CancelFunc
+
+	stopOnce sync.Once
+	// drained is closed once the servers stopped and in-flight requests completed
+	drained chan struct{}
+	// served is closed once Start returns
+	served chan struct{}
+}
+
+// httpListener is an HTTP server of a run and the listener it serves on
+type httpListener struct {
+	name   string
+	server *http.Server
+	lis    net.Listener
 }
 
 type Node interface {
-	Certifier
-	Start() error
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	Ready() <-chan struct{}
 }
 
 // NewBaseNode creates a new base node implementation
 func NewBaseNode(config Config, certifier Certifier) *BaseNode {
+	return newBaseNode(config, AdaptCertifier(certifier), certifier)
+}
+
+// NewContextBaseNode creates a new base node implementation whose certifier is passed
+// the context of every request
+func NewContextBaseNode(config Config, certifier ContextCertifier) *BaseNode {
+	return newBaseNode(config, certifier, certifier)
+}
+
+func newBaseNode(config Config, certifier ContextCertifier, implementation any) *BaseNode {
+	registry := config.MetricsRegistry
+	if registry == nil {
+		registry = prometheus.NewRegistry()
+		registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	}
 	return &BaseNode{
-		config:    config,
-		certifier: certifier,
+		config:          config,
+		certifier:       certifier,
+		implementation:  implementation,
+		metrics:         service.NewMetrics(registry),
+		metricsGatherer: registry,
+		ready:           make(chan struct{}),
+	}
+}
+
+// Listeners are the listeners a node serves on. The HTTP/JSON gateway and the metrics
+// are only served if their listener is set.
+type Listeners struct {
+	Grpc    net.Listener
+	Http    net.Listener
+	Metrics net.Listener
+}
+
+func (l Listeners) close() {
+	for _, lis := range []net.Listener{l.Grpc, l.Http, l.Metrics} {
+		if lis != nil {
+			lis.Close()
+		}
 	}
 }
 
-// Start implements the Node interface
-func (n *BaseNode) Start() error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", n.config.ServicePort))
+// Start serves the node on the configured ports until ctx is done or Stop is called. In
+// both cases in-flight requests are drained before it returns nil. A stopped node can be
+// started again.
+func (n *BaseNode) Start(ctx context.Context) error {
+	listen := func(port int) (net.Listener, error) {
+		return net.Listen("tcp", fmt.Sprintf(":%d", port))
+	}
+
+	var listeners Listeners
+	var err error
+	listeners.Grpc, err = listen(n.config.ServicePort)
 	if err != nil {
 		return err
 	}
-	return n.StartWithListener(lis)
+	if n.config.HttpPort != 0 {
+		if listeners.Http, err = listen(n.config.HttpPort); err != nil {
+			listeners.close()
+			return err
+		}
+	}
+	if n.config.MetricsPort != 0 {
+		if listeners.Metrics, err = listen(n.config.MetricsPort); err != nil {
+			listeners.close()
+			return err
+		}
+	}
+	return n.StartWithListeners(ctx, listeners)
+}
+
+// StartWithListener is like Start but serves gRPC on lis, which it closes when it
+// returns, and neither the HTTP/JSON gateway nor the metrics
+func (n *BaseNode) StartWithListener(ctx context.Context, lis net.Listener) error {
+	return n.StartWithListeners(ctx, Listeners{Grpc: lis})
+}
+
+// StartWithListeners is like Start but serves on listeners, which it closes when it returns
+func (n *BaseNode) StartWithListeners(ctx context.Context, listeners Listeners) error {
+	if n.config.SigningDomain.ChainId == nil && !n.config.LegacyDigest {
+		listeners.close()
+		return errors.New("a signing domain is required unless the legacy digest is enabled")
+	}
+	certifyingService := n.newCertifyingService()
+	r := &run{
+		addr:    listeners.Grpc.Addr(),
+		health:  health.NewServer(),
+		drained: make(chan struct{}),
+		served:  make(chan struct{}),
+	}
+	var err error
+	r.grpcServer, err = n.newGrpcServer(certifyingService, r.health)
+	if err != nil {
+		listeners.close()
+		return err
+	}
+	if listeners.Http != nil {
+		r.httpAddr = listeners.Http.Addr()
+		httpServer, err := n.newHttpServer(certifyingService)
+		if err != nil {
+			listeners.close()
+			return err
+		}
+		r.httpServers = append(r.httpServers, httpListener{"HTTP gateway", httpServer, listeners.Http})
+	}
+	if listeners.Metrics != nil {
+		r.metricsAddr = listeners.Metrics.Addr()
+		metricsServer := &http.Server{Handler: n.MetricsHandler()}
+		r.httpServers = append(r.httpServers, httpListener{"metrics", metricsServer, listeners.Metrics})
+	}
+
+	healthCtx, stopHealthChecks := context.WithCancel(context.Background())
+	r.stopHealthChecks = stopHealthChecks
+
+	n.mu.Lock()
+	if n.run != nil {
+		n.mu.Unlock()
+		stopHealthChecks()
+		listeners.close()
+		return ErrNodeRunning
+	}
+	n.run = r
+	close(n.ready)
+	n.mu.Unlock()
+
+	go n.checkHealth(healthCtx, r.health)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			n.Stop(context.Background())
+		case <-r.served:
+		}
+	}()
+
+	httpErrC := make(chan error, len(r.httpServers))
+	for _, l := range r.httpServers {
+		go func(l httpListener) {
+			log.Printf("Starting %s on %s", l.name, l.lis.Addr())
+			var err error
+			if l.server.TLSConfig != nil {
+				err = l.server.ServeTLS(l.lis, "", "")
+			} else {
+				err = l.server.Serve(l.lis)
+			}
+			if err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Printf("Failed to serve %s on %s: %v", l.name, l.lis.Addr(), err)
+				httpErrC <- err
+				n.Stop(context.Background())
+			}
+		}(l)
+	}
+
+	log.Printf("Starting server on %s", listeners.Grpc.Addr())
+	err = r.grpcServer.Serve(listeners.Grpc)
+	// the node may have been stopped before it started serving
+	if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		log.Printf("Failed to serve on %s: %v", listeners.Grpc.Addr(), err)
+		canceled, cancel := context.WithCancel(context.Background())
+		cancel()
+		r.stop(canceled)
+	} else {
+		err = nil
+	}
+	<-r.drained
+
+	select {
+	case httpErr := <-httpErrC:
+		err = httpErr
+	default:
+	}
+
+	n.mu.Lock()
+	n.run = nil
+	n.ready = make(chan struct{})
+	close(r.served)
+	n.mu.Unlock()
+	return err
+}
+
+// Stop stops serving and waits for in-flight requests to complete. If ctx is done
+// first, the remaining requests are canceled and ctx's error is returned. Stopping a
+// node that isn't running does nothing.
+func (n *BaseNode) Stop(ctx context.Context) error {
+	n.mu.Lock()
+	r := n.run
+	n.mu.Unlock()
+	if r == nil {
+		return nil
+	}
+
+	err := r.stop(ctx)
+	<-r.served
+	return err
+}
+
+// stop gracefully stops the servers of r, or forcefully once ctx is done
+func (r *run) stop(ctx context.Context) error {
+	r.stopOnce.Do(func() {
+		// tell load balancers and aggregators to stop sending requests
+		r.stopHealthChecks()
+		r.health.Shutdown()
+		go func() {
+			var servers sync.WaitGroup
+			servers.Add(1)
+			go func() {
+				defer servers.Done()
+				r.grpcServer.GracefulStop()
+			}()
+			for _, l := range r.httpServers {
+				servers.Add(1)
+				go func(server *http.Server) {
+					defer servers.Done()
+					server.Shutdown(context.Background())
+				}(l.server)
+			}
+			servers.Wait()
+			close(r.drained)
+		}()
+	})
+
+	select {
+	case <-r.drained:
+		return nil
+	case <-ctx.Done():
+		r.grpcServer.Stop()
+		for _, l := range r.httpServers {
+			l.server.Close()
+		}
+		<-r.drained
+		return ctx.Err()
+	}
+}
+
+// Ready returns a channel that is closed once the node listens for requests. Once the
+// node stopped, Ready returns a new channel for its next start.
+func (n *BaseNode) Ready() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ready
+}
+
+// Addr returns the address the node serves gRPC on, or nil if it isn't running
+func (n *BaseNode) Addr() net.Addr {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.run == nil {
+		return nil
+	}
+	return n.run.addr
+}
+
+// MetricsAddr returns the address the node serves its metrics on, or nil if it isn't
+// running or serving its metrics
+func (n *BaseNode) MetricsAddr() net.Addr {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.run == nil {
+		return nil
+	}
+	return n.run.metricsAddr
+}
+
+// MetricsHandler serves the metrics of the node in the Prometheus exposition format
+func (n *BaseNode) MetricsHandler() http.Handler {
+	return promhttp.HandlerFor(n.metricsGatherer, promhttp.HandlerOpts{})
 }
 
-func (n *BaseNode) StartWithListener(lis net.Listener) error {
-	grpcServer := grpc.NewServer()
+// HttpAddr returns the address the node serves the HTTP/JSON gateway on, or nil if it
+// isn't running or serving the gateway
+func (n *BaseNode) HttpAddr() net.Addr {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.run == nil {
+		return nil
+	}
+	return n.run.httpAddr
+}
 
+func (n *BaseNode) newCertifyingService() *service.CertifyingService {
 	// Create a closure that captures the config for validation
-	getResponse := func(data []byte) ([]byte, error) {
-		return n.certifier.GetResponse(n.config, data)
+	getResponse := func(ctx context.Context, req *v1.CertifyRequest) ([]byte, error) {
+		certifyRequest := CertifyRequest{
+			TaskIndex:            req.TaskIndex,
+			ReferenceBlockNumber: req.ReferenceBlockNumber,
+			Data:                 req.Data,
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			certifyRequest.Peer = p.Addr
+		}
+		return n.certifier.GetResponseContext(ctx, n.config, certifyRequest)
 	}
 
-	v1.RegisterNodeServiceServer(grpcServer, service.NewCertifyingService(
+	var serviceOpts []service.Option
+	if n.config.AggregatorAllowlist != nil {
+		serviceOpts = append(serviceOpts, service.WithAggregatorAllowlist(n.config.AggregatorAllowlist))
+	}
+	if n.config.SlashingProtection != nil {
+		serviceOpts = append(serviceOpts, service.WithSlashingProtection(n.config.SlashingProtection))
+	}
+	if n.config.LegacyDigest {
+		serviceOpts = append(serviceOpts, service.WithLegacyDigest())
+	}
+	if certifier, ok := n.implementation.(TaskTypesCertifier); ok {
+		serviceOpts = append(serviceOpts, service.WithTaskTypes(certifier.TaskTypes()...))
+	}
+	if n.config.TracerProvider != nil {
+		serviceOpts = append(serviceOpts, service.WithTracerProvider(n.config.TracerProvider))
+	}
+	serviceOpts = append(serviceOpts, service.WithMetrics(n.metrics))
+	return service.NewCertifyingService(
 		n.config.BlsKeyPair,
 		getResponse,
-	))
+		n.config.SigningDomain,
+		serviceOpts...,
+	)
+}
+
+// checkHealth reports the node as serving while the health check of its certifier
+// passes, until ctx is done
+func (n *BaseNode) checkHealth(ctx context.Context, healthServer *health.Server) {
+	setStatus := func(status healthpb.HealthCheckResponse_ServingStatus) {
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(v1.NodeService_ServiceDesc.ServiceName, status)
+	}
 
+	checker, ok := n.implementation.(HealthChecker)
+	if !ok {
+		setStatus(healthpb.HealthCheckResponse_SERVING)
+		return
+	}
+
+	interval := n.config.HealthCheckInterval
+	if interval == 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		checkCtx, cancel := context.WithTimeout(ctx, interval)
+		err := checker.CheckHealth(checkCtx)
+		cancel()
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Printf("Health check failed: %v", err)
+			setStatus(healthpb.HealthCheckResponse_NOT_SERVING)
+		} else {
+			setStatus(healthpb.HealthCheckResponse_SERVING)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (n *BaseNode) newGrpcServer(certifyingService *service.CertifyingService, healthServer *health.Server) (*grpc.Server, error) {
+	var opts []grpc.ServerOption
+	if n.config.TLS.CertFile != "" {
+		tlsConfig, err := common.NewServerTLSConfig(n.config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	tracerProvider := n.config.TracerProvider
+	if tracerProvider == nil {
+		tracerProvider = otel.GetTracerProvider()
+	}
+	opts = append(opts, grpc.StatsHandler(otelgrpc.NewServerHandler(
+		otelgrpc.WithTracerProvider(tracerProvider),
+		otelgrpc.WithPropagators(common.TracePropagator),
+	)))
+	grpcServer := grpc.NewServer(opts...)
+	v1.RegisterNodeServiceServer(grpcServer, certifyingService)
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 	reflection.Register(grpcServer)
+	return grpcServer, nil
+}
 
-	log.Printf("Starting server on port %d", n.config.ServicePort)
-	if err := grpcServer.Serve(lis); err != nil {
-		log.Printf("Failed to serve on port %d: %v", n.config.ServicePort, err)
-		return err
+// newHttpServer creates the server of the HTTP/JSON gateway, which serves
+// POST /node.v1.NodeService/Certify and the swagger document at /swagger.yaml
+func (n *BaseNode) newHttpServer(certifyingService *service.CertifyingService) (*http.Server, error) {
+	gateway := runtime.NewServeMux()
+	if err := v1.RegisterNodeServiceHandlerServer(context.Background(), gateway, certifyingService); err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /swagger.yaml", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Write(swagger.Spec)
+	})
+	mux.Handle("/", withPeer(gateway))
+
+	httpServer := &http.Server{Handler: mux}
+	if n.config.TLS.CertFile != "" {
+		tlsConfig, err := common.NewServerTLSConfig(n.config.TLS)
+		if err != nil {
+			return nil, err
+		}
+		// unlike gRPC clients, HTTP clients may not speak HTTP/2
+		getConfigForClient := tlsConfig.GetConfigForClient
+		tlsConfig.GetConfigForClient = func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+			config, err := getConfigForClient(hello)
+			if err != nil {
+				return nil, err
+			}
+			config.NextProtos = []string{"h2", "http/1.1"}
+			return config, nil
+		}
+		httpServer.TLSConfig = tlsConfig
 	}
-	return nil
+	return httpServer, nil
+}
+
+// withPeer sets the peer of gateway requests, which don't go through a gRPC server
+func withPeer(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+			r = r.WithContext(peer.NewContext(r.Context(), &peer.Peer{Addr: addr}))
+		}
+		next.ServeHTTP(w, r)
+	})
 }
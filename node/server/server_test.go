@@ -0,0 +1,456 @@
+package server_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	"github.com/Layr-Labs/eigensdk-go/testutils"
+	"github.com/Layr-Labs/eigensdk-go/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	operatorrequester "github.com/Layr-Labs/teal/aggregator/operator_requester"
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/api/swagger"
+	"github.com/Layr-Labs/teal/common"
+	"github.com/Layr-Labs/teal/node/server"
+	"github.com/Layr-Labs/teal/node/service"
+)
+
+// testDomain is the signing domain of the tests' nodes
+var testDomain = common.SigningDomain{
+	ChainId:         big.NewInt(31337),
+	VerifierAddress: gethcommon.HexToAddress("0x4242424242424242424242424242424242424242"),
+}
+
+type certifierFunc func(ctx context.Context, config server.Config, req server.CertifyRequest) ([]byte, error)
+
+func (f certifierFunc) GetResponseContext(ctx context.Context, config server.Config, req server.CertifyRequest) ([]byte, error) {
+	return f(ctx, config, req)
+}
+
+type legacyCertifier struct{}
+
+func (legacyCertifier) GetResponse(_ server.Config, data []byte) ([]byte, error) {
+	return append([]byte("legacy "), data...), nil
+}
+
+// startNode serves node on a local port and returns a client connected to it
+func startNode(t *testing.T, node *server.BaseNode) v1.NodeServiceClient {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go node.StartWithListener(context.Background(), lis)
+	t.Cleanup(func() { node.Stop(context.Background()) })
+	<-node.Ready()
+
+	return dial(t, node.Addr().String())
+}
+
+func dial(t *testing.T, addr string) v1.NodeServiceClient {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return v1.NewNodeServiceClient(conn)
+}
+
+func TestContextCertifier(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	config := server.Config{BlsKeyPair: keyPair, SigningDomain: testDomain}
+
+	t.Run("request metadata", func(t *testing.T) {
+		requests := make(chan server.CertifyRequest, 1)
+		deadlines := make(chan time.Time, 1)
+		client := startNode(t, server.NewContextBaseNode(config, certifierFunc(
+			func(ctx context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+				deadline, _ := ctx.Deadline()
+				deadlines <- deadline
+				requests <- req
+				return req.Data, nil
+			},
+		)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		_, err := client.Certify(ctx, &v1.CertifyRequest{TaskIndex: 3, ReferenceBlockNumber: 100, Data: []byte("data")})
+		require.NoError(t, err)
+
+		req := <-requests
+		assert.Equal(t, uint32(3), req.TaskIndex)
+		assert.Equal(t, uint32(100), req.ReferenceBlockNumber)
+		assert.Equal(t, []byte("data"), req.Data)
+		require.NotNil(t, req.Peer)
+		assert.Contains(t, req.Peer.String(), "127.0.0.1")
+		assert.WithinDuration(t, time.Now().Add(time.Minute), <-deadlines, 5*time.Second)
+	})
+
+	t.Run("canceled with the request", func(t *testing.T) {
+		canceled := make(chan error, 1)
+		client := startNode(t, server.NewContextBaseNode(config, certifierFunc(
+			func(ctx context.Context, _ server.Config, _ server.CertifyRequest) ([]byte, error) {
+				<-ctx.Done()
+				canceled <- ctx.Err()
+				return nil, errors.New("aborted")
+			},
+		)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err := client.Certify(ctx, &v1.CertifyRequest{Data: []byte("data")})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+		select {
+		case err := <-canceled:
+			assert.Error(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("certifier was not canceled")
+		}
+	})
+
+	t.Run("adapted certifier", func(t *testing.T) {
+		client := startNode(t, server.NewBaseNode(config, legacyCertifier{}))
+
+		resp, err := client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("data")})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("legacy data"), resp.Data)
+	})
+}
+
+func TestBaseNodeLifecycle(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	config := server.Config{BlsKeyPair: keyPair, SigningDomain: testDomain}
+
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	node := server.NewContextBaseNode(config, certifierFunc(
+		func(ctx context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+			if string(req.Data) == "block" {
+				started <- struct{}{}
+				select {
+				case <-release:
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			}
+			return req.Data, nil
+		},
+	))
+
+	serve := func(ctx context.Context) (v1.NodeServiceClient, chan error) {
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		served := make(chan error, 1)
+		go func() {
+			served <- node.StartWithListener(ctx, lis)
+		}()
+		<-node.Ready()
+		return dial(t, node.Addr().String()), served
+	}
+
+	t.Run("stop drains in-flight requests", func(t *testing.T) {
+		client, served := serve(context.Background())
+
+		// a running node can't be started twice
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		assert.ErrorIs(t, node.StartWithListener(context.Background(), lis), server.ErrNodeRunning)
+
+		certified := make(chan error, 1)
+		go func() {
+			_, err := client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("block")})
+			certified <- err
+		}()
+		<-started
+
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- node.Stop(context.Background())
+		}()
+		select {
+		case <-stopped:
+			t.Fatal("stop returned before the in-flight request completed")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		close(release)
+		assert.NoError(t, <-certified)
+		assert.NoError(t, <-stopped)
+		assert.NoError(t, <-served)
+		assert.Nil(t, node.Addr())
+	})
+
+	t.Run("restarts and stops when its context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		client, served := serve(ctx)
+
+		resp, err := client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("data")})
+		require.NoError(t, err)
+		assert.Equal(t, []byte("data"), resp.Data)
+
+		cancel()
+		select {
+		case err := <-served:
+			assert.NoError(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("node did not stop")
+		}
+	})
+
+	t.Run("stop cancels requests once its context is done", func(t *testing.T) {
+		release = make(chan struct{})
+		client, served := serve(context.Background())
+
+		certified := make(chan error, 1)
+		go func() {
+			_, err := client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("block")})
+			certified <- err
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, node.Stop(ctx), context.DeadlineExceeded)
+		assert.Error(t, <-certified)
+		assert.NoError(t, <-served)
+	})
+
+	t.Run("a signing domain is required unless the legacy digest is enabled", func(t *testing.T) {
+		node := server.NewBaseNode(server.Config{BlsKeyPair: keyPair}, legacyCertifier{})
+		lis, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		assert.Error(t, node.StartWithListener(context.Background(), lis))
+
+		node = server.NewBaseNode(server.Config{BlsKeyPair: keyPair, LegacyDigest: true}, legacyCertifier{})
+		client := startNode(t, node)
+		_, err = client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("data")})
+		assert.NoError(t, err)
+	})
+}
+
+func TestHttpGateway(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	peers := make(chan net.Addr, 1)
+	node := server.NewContextBaseNode(server.Config{BlsKeyPair: keyPair, SigningDomain: testDomain}, certifierFunc(
+		func(_ context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+			peers <- req.Peer
+			return req.Data, nil
+		},
+	))
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	httpLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go node.StartWithListeners(context.Background(), server.Listeners{Grpc: lis, Http: httpLis})
+	t.Cleanup(func() { node.Stop(context.Background()) })
+	<-node.Ready()
+	baseUrl := "http://" + node.HttpAddr().String()
+
+	t.Run("certify", func(t *testing.T) {
+		body := fmt.Sprintf(`{"taskIndex": 1, "data": %q}`, base64.StdEncoding.EncodeToString([]byte("data")))
+		resp, err := http.Post(baseUrl+"/node.v1.NodeService/Certify", "application/json", strings.NewReader(body))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var certifyResponse struct {
+			Signature []byte `json:"signature"`
+			Data      []byte `json:"data"`
+		}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&certifyResponse))
+		assert.Equal(t, []byte("data"), certifyResponse.Data)
+		assert.NotEmpty(t, certifyResponse.Signature)
+		assert.NotNil(t, <-peers)
+	})
+
+	t.Run("swagger", func(t *testing.T) {
+		resp, err := http.Get(baseUrl + "/swagger.yaml")
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		spec, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, swagger.Spec, spec)
+	})
+}
+
+type healthCheckedCertifier struct {
+	certifierFunc
+	healthy atomic.Bool
+}
+
+func (c *healthCheckedCertifier) CheckHealth(context.Context) error {
+	if !c.healthy.Load() {
+		return errors.New("rpc endpoint is unreachable")
+	}
+	return nil
+}
+
+func (c *healthCheckedCertifier) TaskTypes() []string {
+	return []string{"echo"}
+}
+
+func TestHealthAndInfo(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	certifier := &healthCheckedCertifier{certifierFunc: func(_ context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+		return req.Data, nil
+	}}
+	node := server.NewContextBaseNode(server.Config{BlsKeyPair: keyPair, SigningDomain: testDomain, HealthCheckInterval: 10 * time.Millisecond}, certifier)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go node.StartWithListener(context.Background(), lis)
+	t.Cleanup(func() { node.Stop(context.Background()) })
+	<-node.Ready()
+
+	conn, err := grpc.NewClient(node.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	t.Run("info", func(t *testing.T) {
+		info, err := v1.NewNodeServiceClient(conn).GetInfo(context.Background(), &v1.GetInfoRequest{})
+		require.NoError(t, err)
+		operatorId := types.OperatorIdFromKeyPair(keyPair)
+		assert.Equal(t, operatorId[:], info.OperatorId)
+		assert.Equal(t, keyPair.GetPubKeyG1().Serialize(), info.BlsPubkeyG1)
+		assert.Equal(t, keyPair.GetPubKeyG2().Serialize(), info.BlsPubkeyG2)
+		assert.Equal(t, []string{"echo"}, info.TaskTypes)
+		assert.NotEmpty(t, info.Version)
+	})
+
+	t.Run("readiness follows the health check", func(t *testing.T) {
+		healthClient := healthpb.NewHealthClient(conn)
+		status := func() healthpb.HealthCheckResponse_ServingStatus {
+			resp, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: v1.NodeService_ServiceDesc.ServiceName})
+			if err != nil {
+				return healthpb.HealthCheckResponse_UNKNOWN
+			}
+			return resp.Status
+		}
+
+		assert.Eventually(t, func() bool { return status() == healthpb.HealthCheckResponse_NOT_SERVING }, time.Second, 5*time.Millisecond)
+		certifier.healthy.Store(true)
+		assert.Eventually(t, func() bool { return status() == healthpb.HealthCheckResponse_SERVING }, time.Second, 5*time.Millisecond)
+		certifier.healthy.Store(false)
+		assert.Eventually(t, func() bool { return status() == healthpb.HealthCheckResponse_NOT_SERVING }, time.Second, 5*time.Millisecond)
+	})
+}
+
+func TestMetrics(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	certifier := &healthCheckedCertifier{certifierFunc: func(_ context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+		if string(req.Data) == "down" {
+			return nil, fmt.Errorf("%w: rpc down", service.ErrUnavailable)
+		}
+		return req.Data, nil
+	}}
+	certifier.healthy.Store(true)
+	node := server.NewContextBaseNode(server.Config{BlsKeyPair: keyPair, SigningDomain: testDomain}, certifier)
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	metricsLis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go node.StartWithListeners(context.Background(), server.Listeners{Grpc: lis, Metrics: metricsLis})
+	t.Cleanup(func() { node.Stop(context.Background()) })
+	<-node.Ready()
+
+	client := dial(t, node.Addr().String())
+	_, err = client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("data")})
+	require.NoError(t, err)
+	_, err = client.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("down")})
+	require.Error(t, err)
+
+	resp, err := http.Get("http://" + node.MetricsAddr().String() + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	metrics := string(body)
+
+	assert.Contains(t, metrics, `teal_node_certify_requests_total{code="OK",task_type="echo"} 1`)
+	assert.Contains(t, metrics, `teal_node_certify_requests_total{code="Unavailable",task_type="echo"} 1`)
+	assert.Contains(t, metrics, `teal_node_certify_errors_total{class="unavailable",task_type="echo"} 1`)
+	assert.Contains(t, metrics, `teal_node_certify_requests_in_flight{task_type="echo"} 0`)
+	assert.Contains(t, metrics, `teal_node_signing_duration_seconds_count{task_type="echo"} 1`)
+	assert.Contains(t, metrics, `teal_node_certify_request_duration_seconds_count{code="OK",task_type="echo"} 1`)
+	assert.Contains(t, metrics, "go_goroutines")
+}
+
+func TestTracePropagation(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+
+	nodeSpans := tracetest.NewSpanRecorder()
+	certifierSpans := make(chan trace.SpanContext, 1)
+	node := server.NewContextBaseNode(server.Config{
+		BlsKeyPair:     keyPair,
+		SigningDomain:  testDomain,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(nodeSpans)),
+	}, certifierFunc(func(ctx context.Context, _ server.Config, req server.CertifyRequest) ([]byte, error) {
+		certifierSpans <- trace.SpanContextFromContext(ctx)
+		return req.Data, nil
+	}))
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go node.StartWithListener(context.Background(), lis)
+	t.Cleanup(func() { node.Stop(context.Background()) })
+	<-node.Ready()
+
+	aggregatorSpans := tracetest.NewSpanRecorder()
+	aggregatorTracing := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(aggregatorSpans))
+	requester := operatorrequester.NewOperatorRequester(
+		testutils.GetTestLogger(),
+		operatorrequester.WithTracerProvider(aggregatorTracing),
+	)
+	operator := types.OperatorAvsState{
+		OperatorId:   types.OperatorId{1},
+		OperatorInfo: types.OperatorInfo{Socket: types.Socket(node.Addr().String())},
+	}
+
+	ctx, span := aggregatorTracing.Tracer("test").Start(context.Background(), "GetCertificate")
+	_, err = requester.RequestCertification(ctx, operator, 1, 1, []byte("data"))
+	span.End()
+	require.NoError(t, err)
+
+	traceId := span.SpanContext().TraceID()
+	assert.Equal(t, traceId, (<-certifierSpans).TraceID())
+
+	names := make(map[string]trace.TraceID)
+	for _, span := range nodeSpans.Ended() {
+		names[span.Name()] = span.SpanContext().TraceID()
+	}
+	assert.Equal(t, traceId, names[v1.NodeService_Certify_FullMethodName[1:]])
+	assert.Equal(t, traceId, names["Certifier"])
+	assert.Equal(t, traceId, names["Sign"])
+
+	clientSpans := aggregatorSpans.Ended()
+	require.Len(t, clientSpans, 2)
+	assert.Equal(t, v1.NodeService_Certify_FullMethodName[1:], clientSpans[0].Name())
+	assert.Equal(t, trace.SpanKindClient, clientSpans[0].SpanKind())
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// AggregatorAllowlist decides which aggregators may request certifications
+type AggregatorAllowlist interface {
+	IsAllowed(ctx context.Context, aggregator gethcommon.Address) (bool, error)
+}
+
+type staticAllowlist map[gethcommon.Address]struct{}
+
+// NewStaticAllowlist allows the given aggregator addresses
+func NewStaticAllowlist(aggregators ...gethcommon.Address) AggregatorAllowlist {
+	allowlist := make(staticAllowlist, len(aggregators))
+	for _, aggregator := range aggregators {
+		allowlist[aggregator] = struct{}{}
+	}
+	return allowlist
+}
+
+func (a staticAllowlist) IsAllowed(_ context.Context, aggregator gethcommon.Address) (bool, error) {
+	_, ok := a[aggregator]
+	return ok, nil
+}
+
+// AggregatorsFetcher reads the addresses of the allowed aggregators, for example from a contract
+type AggregatorsFetcher func(ctx context.Context) ([]gethcommon.Address, error)
+
+// allowlistFetchTimeout bounds a refresh of the chain allowlist, which doesn't end with the
+// request that started it
+const allowlistFetchTimeout = 30 * time.Second
+
+type chainAllowlist struct {
+	fetch           AggregatorsFetcher
+	refreshInterval time.Duration
+
+	mu sync.Mutex
+	// aggregators is replaced as a whole on every refresh, so it can be read without mu
+	aggregators map[gethcommon.Address]struct{}
+	fetchedAt   time.Time
+	// refreshing is closed once the running refresh finished, it is nil if none is running
+	refreshing chan struct{}
+	// refreshErr is the error of the last refresh
+	refreshErr error
+}
+
+// NewChainAllowlist allows the aggregators returned by fetch, which is called again once
+// the addresses are older than refreshInterval. Requests are served from the last known
+// addresses while they are fetched again, or if fetching fails. Only the first requests
+// wait for fetch.
+func NewChainAllowlist(fetch AggregatorsFetcher, refreshInterval time.Duration) AggregatorAllowlist {
+	return &chainAllowlist{
+		fetch:           fetch,
+		refreshInterval: refreshInterval,
+	}
+}
+
+func (a *chainAllowlist) IsAllowed(ctx context.Context, aggregator gethcommon.Address) (bool, error) {
+	a.mu.Lock()
+	if a.refreshing == nil && (a.aggregators == nil || time.Since(a.fetchedAt) >= a.refreshInterval) {
+		a.refreshing = make(chan struct{})
+		go a.refresh(context.WithoutCancel(ctx), a.refreshing)
+	}
+	aggregators, refreshing := a.aggregators, a.refreshing
+	a.mu.Unlock()
+
+	if aggregators == nil {
+		// nothing to serve before the first addresses were fetched
+		select {
+		case <-refreshing:
+		case <-ctx.Done():
+			return false, ctx.Err()
+		}
+		var err error
+		a.mu.Lock()
+		aggregators, err = a.aggregators, a.refreshErr
+		a.mu.Unlock()
+		if aggregators == nil {
+			return false, err
+		}
+	}
+
+	_, ok := aggregators[aggregator]
+	return ok, nil
+}
+
+// refresh fetches the aggregators and closes done once they are updated
+func (a *chainAllowlist) refresh(ctx context.Context, done chan struct{}) {
+	ctx, cancel := context.WithTimeout(ctx, allowlistFetchTimeout)
+	defer cancel()
+	fetched, err := a.fetch(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	defer close(done)
+
+	a.refreshing = nil
+	a.refreshErr = err
+	if err != nil {
+		return
+	}
+	aggregators := make(map[gethcommon.Address]struct{}, len(fetched))
+	for _, address := range fetched {
+		aggregators[address] = struct{}{}
+	}
+	a.aggregators = aggregators
+	a.fetchedAt = time.Now()
+}
@@ -2,43 +2,207 @@ package service
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	v1 "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/common"
+	"github.com/Layr-Labs/teal/node/protection"
 )
 
+// TracerName is the name of the tracer the node records its spans with
+const TracerName = "github.com/Layr-Labs/teal/node"
+
 type CertifyingService struct {
 	keyPair     *bls.KeyPair
-	getResponse func(data []byte) ([]byte, error)
+	getResponse func(ctx context.Context, req *v1.CertifyRequest) ([]byte, error)
+	allowlist   AggregatorAllowlist
+	protection  protection.Database
+	domain      common.SigningDomain
+	// legacyDigest signs keccak256(response) instead of the digests of domain
+	legacyDigest bool
+	taskTypes    []string
+	metrics      *Metrics
+	tracer       trace.Tracer
+	// taskType labels the metrics of the service
+	taskType string
 
 	v1.UnsafeNodeServiceServer
 }
 
+// Option configures optional behaviour of the CertifyingService
+type Option func(*CertifyingService)
+
+// WithAggregatorAllowlist only serves requests signed by an aggregator in allowlist.
+// Without it, anyone who can reach the node can request certifications.
+func WithAggregatorAllowlist(allowlist AggregatorAllowlist) Option {
+	return func(s *CertifyingService) {
+		s.allowlist = allowlist
+	}
+}
+
+// WithSlashingProtection refuses to sign a response for a task index of an aggregator that
+// another response was already signed for, as recorded in db. Without an allowlist,
+// requests are not authenticated and all aggregators share the same task indices.
+func WithSlashingProtection(db protection.Database) Option {
+	return func(s *CertifyingService) {
+		s.protection = db
+	}
+}
+
+// WithLegacyDigest signs the legacy digest keccak256(response) instead of the digests of
+// the signing domain. Such a signature is valid for any deployment certifying the same
+// response, so it can be replayed on other chains and verifiers.
+func WithLegacyDigest() Option {
+	return func(s *CertifyingService) {
+		s.legacyDigest = true
+	}
+}
+
+// WithTaskTypes advertises the task types the node certifies in GetInfo
+func WithTaskTypes(taskTypes ...string) Option {
+	return func(s *CertifyingService) {
+		s.taskTypes = taskTypes
+	}
+}
+
+// WithMetrics records the requests served in metrics
+func WithMetrics(metrics *Metrics) Option {
+	return func(s *CertifyingService) {
+		s.metrics = metrics
+	}
+}
+
+// WithTracerProvider records the spans of the service with provider instead of the
+// global tracer provider
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *CertifyingService) {
+		s.tracer = provider.Tracer(TracerName)
+	}
+}
+
+// NewCertifyingService creates a service signing the digests of domain, which bind a
+// signature to the chain, verifier, task and reference block it was produced for. See
+// WithLegacyDigest for aggregators expecting keccak256(response).
 func NewCertifyingService(
 	kp *bls.KeyPair,
-	getResponse func(data []byte) ([]byte, error),
+	getResponse func(ctx context.Context, req *v1.CertifyRequest) ([]byte, error),
+	domain common.SigningDomain,
+	opts ...Option,
 ) *CertifyingService {
-	return &CertifyingService{
+	s := &CertifyingService{
 		keyPair:     kp,
 		getResponse: getResponse,
+		domain:      domain,
+	}
+	for _, opt := range opts {
+		opt(s)
 	}
+	if s.tracer == nil {
+		s.tracer = otel.Tracer(TracerName)
+	}
+	s.taskType = UnknownTaskType
+	if len(s.taskTypes) > 0 {
+		s.taskType = strings.Join(s.taskTypes, ",")
+	}
+	return s
 }
 
 func (s *CertifyingService) Certify(ctx context.Context, req *v1.CertifyRequest) (*v1.CertifyResponse, error) {
-	response, err := s.getResponse(req.Data)
+	defer s.metrics.requestStarted(s.taskType)()
+	start := time.Now()
+	resp, class, err := s.certify(ctx, req)
+	s.metrics.observeRequest(s.taskType, time.Since(start), err, class)
+	return resp, err
+}
+
+// certify serves req, if it fails it also returns the class of the failure
+func (s *CertifyingService) certify(ctx context.Context, req *v1.CertifyRequest) (*v1.CertifyResponse, string, error) {
+	if !s.legacyDigest && s.domain.ChainId == nil {
+		return nil, "misconfigured", status.Error(codes.FailedPrecondition, "the node has no signing domain")
+	}
+
+	aggregator, err := s.authenticate(ctx, req)
+	if err != nil {
+		return nil, "unauthenticated", err
+	}
+
+	certifierCtx, span := s.tracer.Start(ctx, "Certifier", trace.WithAttributes(
+		attribute.Int64("teal.task_index", int64(req.TaskIndex)),
+		attribute.Int64("teal.reference_block_number", int64(req.ReferenceBlockNumber)),
+	))
+	response, err := s.getResponse(certifierCtx, req)
 	if err != nil {
-		return nil, status.Errorf(codes.InvalidArgument, "data is invalid: %v", err)
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+	if ctx.Err() != nil {
+		return nil, "canceled", status.FromContextError(ctx.Err()).Err()
+	}
+	if err != nil {
+		_, reason := classifyCertifierError(err)
+		return nil, strings.ToLower(reason), certifierStatus(err).Err()
 	}
 
-	digest := crypto.Keccak256(response)
-	digestBytes := [32]byte(digest)
+	_, span = s.tracer.Start(ctx, "Sign")
+	defer span.End()
+	signingStart := time.Now()
+	digestBytes := s.digest(req, response)
+
+	if s.protection != nil {
+		err := s.protection.CheckAndRecord(aggregator, req.TaskIndex, digestBytes)
+		if errors.Is(err, protection.ErrConflictingSignature) {
+			return nil, "conflicting_signature", status.Errorf(codes.FailedPrecondition, "refusing to sign: %v", err)
+		}
+		if err != nil {
+			return nil, "slashing_protection", status.Errorf(codes.Internal, "failed to record signature: %v", err)
+		}
+	}
 
 	signature := s.keyPair.SignMessage(digestBytes)
 	signatureBytes := signature.Marshal()
+	s.metrics.observeSigning(s.taskType, time.Since(signingStart))
 
-	return &v1.CertifyResponse{Signature: signatureBytes[:], Data: response}, nil
+	return &v1.CertifyResponse{Signature: signatureBytes[:], Data: response}, "", nil
+}
+
+// digest returns the digest the node signs for response to req
+func (s *CertifyingService) digest(req *v1.CertifyRequest, response []byte) [32]byte {
+	if s.legacyDigest {
+		return [32]byte(crypto.Keccak256(response))
+	}
+	return s.domain.Digest(req.TaskIndex, req.ReferenceBlockNumber, response)
+}
+
+// authenticate checks that req was signed by an allowed aggregator and returns its
+// address, or the zero address if there is no allowlist
+func (s *CertifyingService) authenticate(ctx context.Context, req *v1.CertifyRequest) (gethcommon.Address, error) {
+	if s.allowlist == nil {
+		return gethcommon.Address{}, nil
+	}
+
+	aggregator, err := common.RecoverCertifyRequestSigner(req)
+	if err != nil {
+		return gethcommon.Address{}, status.Errorf(codes.PermissionDenied, "request is not authenticated: %v", err)
+	}
+	allowed, err := s.allowlist.IsAllowed(ctx, aggregator)
+	if err != nil {
+		return gethcommon.Address{}, status.Errorf(codes.Unavailable, "failed to check aggregator allowlist: %v", err)
+	}
+	if !allowed {
+		return gethcommon.Address{}, status.Errorf(codes.PermissionDenied, "aggregator %s is not allowed", aggregator.Hex())
+	}
+	return aggregator, nil
 }
@@ -0,0 +1,314 @@
+package service_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/common"
+	"github.com/Layr-Labs/teal/node/protection"
+	"github.com/Layr-Labs/teal/node/service"
+)
+
+// testDomain is the signing domain of the tests' nodes
+var testDomain = common.SigningDomain{
+	ChainId:         big.NewInt(31337),
+	VerifierAddress: gethcommon.HexToAddress("0x4242424242424242424242424242424242424242"),
+}
+
+func echo(_ context.Context, req *v1.CertifyRequest) ([]byte, error) {
+	return req.Data, nil
+}
+
+func TestCertifyingServiceAuthentication(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	aggregatorKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	aggregator := crypto.PubkeyToAddress(aggregatorKey.PublicKey)
+
+	signedRequest := func(t *testing.T, key *ecdsa.PrivateKey) *v1.CertifyRequest {
+		req := &v1.CertifyRequest{TaskIndex: 1, Data: []byte("data")}
+		if key != nil {
+			require.NoError(t, common.SignCertifyRequest(req, key))
+		}
+		return req
+	}
+
+	t.Run("static allowlist", func(t *testing.T) {
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain, service.WithAggregatorAllowlist(service.NewStaticAllowlist(aggregator)))
+
+		_, err := certifyingService.Certify(context.Background(), signedRequest(t, nil))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		_, err = certifyingService.Certify(context.Background(), signedRequest(t, otherKey))
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		// a signature over other data recovers another address
+		req := signedRequest(t, aggregatorKey)
+		req.Data = []byte("tampered")
+		_, err = certifyingService.Certify(context.Background(), req)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		resp, err := certifyingService.Certify(context.Background(), signedRequest(t, aggregatorKey))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("data"), resp.Data)
+	})
+
+	t.Run("chain allowlist", func(t *testing.T) {
+		fetched := []gethcommon.Address{}
+		var fetchErr error
+		allowlist := service.NewChainAllowlist(func(context.Context) ([]gethcommon.Address, error) {
+			return fetched, fetchErr
+		}, time.Hour)
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain, service.WithAggregatorAllowlist(allowlist))
+
+		fetchErr = errors.New("rpc unavailable")
+		_, err := certifyingService.Certify(context.Background(), signedRequest(t, aggregatorKey))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+
+		fetched, fetchErr = []gethcommon.Address{aggregator}, nil
+		_, err = certifyingService.Certify(context.Background(), signedRequest(t, aggregatorKey))
+		assert.NoError(t, err)
+
+		// the fetched addresses are cached until the refresh interval passed
+		fetched = nil
+		_, err = certifyingService.Certify(context.Background(), signedRequest(t, aggregatorKey))
+		assert.NoError(t, err)
+	})
+
+	t.Run("chain allowlist is refreshed without holding up requests", func(t *testing.T) {
+		var mu sync.Mutex
+		fetches := 0
+		release := make(chan struct{})
+		allowlist := service.NewChainAllowlist(func(ctx context.Context) ([]gethcommon.Address, error) {
+			mu.Lock()
+			fetches++
+			first := fetches == 1
+			mu.Unlock()
+			if !first {
+				<-release
+			}
+			return []gethcommon.Address{aggregator}, nil
+		}, 10*time.Millisecond)
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain, service.WithAggregatorAllowlist(allowlist))
+
+		_, err := certifyingService.Certify(context.Background(), signedRequest(t, aggregatorKey))
+		require.NoError(t, err)
+		time.Sleep(20 * time.Millisecond)
+
+		// the stale addresses are served while a single refresh is stuck
+		var wg sync.WaitGroup
+		for range 10 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+				defer cancel()
+				_, err := certifyingService.Certify(ctx, signedRequest(t, aggregatorKey))
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.Eventually(t, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return fetches == 2
+		}, time.Second, time.Millisecond)
+		close(release)
+	})
+
+	t.Run("chain allowlist without addresses waits for the request deadline", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		allowlist := service.NewChainAllowlist(func(ctx context.Context) ([]gethcommon.Address, error) {
+			<-release
+			return []gethcommon.Address{aggregator}, nil
+		}, time.Hour)
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain, service.WithAggregatorAllowlist(allowlist))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := certifyingService.Certify(ctx, signedRequest(t, aggregatorKey))
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("requests are not authenticated without allowlist", func(t *testing.T) {
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain)
+
+		_, err := certifyingService.Certify(context.Background(), signedRequest(t, nil))
+		assert.NoError(t, err)
+	})
+}
+
+func TestCertifyingServiceSlashingProtection(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+
+	response := []byte("first")
+	getResponse := func(context.Context, *v1.CertifyRequest) ([]byte, error) {
+		return response, nil
+	}
+	certifyingService := service.NewCertifyingService(keyPair, getResponse, testDomain, service.WithSlashingProtection(protection.NewInMemoryDatabase()))
+
+	req := &v1.CertifyRequest{TaskIndex: 1, Data: []byte("data")}
+	_, err = certifyingService.Certify(context.Background(), req)
+	assert.NoError(t, err)
+	// the same response can be signed again
+	_, err = certifyingService.Certify(context.Background(), req)
+	assert.NoError(t, err)
+
+	response = []byte("second")
+	_, err = certifyingService.Certify(context.Background(), req)
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+	_, err = certifyingService.Certify(context.Background(), &v1.CertifyRequest{TaskIndex: 2, Data: []byte("data")})
+	assert.NoError(t, err)
+
+	t.Run("aggregators have their own task indices", func(t *testing.T) {
+		aggregatorKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		otherKey, err := crypto.GenerateKey()
+		require.NoError(t, err)
+		allowlist := service.NewStaticAllowlist(crypto.PubkeyToAddress(aggregatorKey.PublicKey), crypto.PubkeyToAddress(otherKey.PublicKey))
+		certifyingService := service.NewCertifyingService(keyPair, echo, testDomain,
+			service.WithAggregatorAllowlist(allowlist),
+			service.WithSlashingProtection(protection.NewInMemoryDatabase()))
+
+		certify := func(key *ecdsa.PrivateKey, data string) error {
+			req := &v1.CertifyRequest{TaskIndex: 1, Data: []byte(data)}
+			require.NoError(t, common.SignCertifyRequest(req, key))
+			_, err := certifyingService.Certify(context.Background(), req)
+			return err
+		}
+		assert.NoError(t, certify(aggregatorKey, "first"))
+		assert.NoError(t, certify(otherKey, "second"))
+		assert.Equal(t, codes.FailedPrecondition, status.Code(certify(otherKey, "first")))
+	})
+}
+
+func TestCertifyingServiceSigningDomain(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+	domain := common.SigningDomain{
+		ChainId:         big.NewInt(17000),
+		VerifierAddress: gethcommon.HexToAddress("0x4242424242424242424242424242424242424242"),
+	}
+	req := &v1.CertifyRequest{TaskIndex: 1, ReferenceBlockNumber: 100, Data: []byte("data")}
+
+	decode := func(t *testing.T, resp *v1.CertifyResponse) *bls.Signature {
+		signature := &bls.Signature{G1Point: bls.NewG1Point(big.NewInt(0), big.NewInt(0))}
+		_, err := signature.SetBytes(resp.Signature)
+		require.NoError(t, err)
+		return signature
+	}
+
+	t.Run("domain separated digest", func(t *testing.T) {
+		certifyingService := service.NewCertifyingService(keyPair, echo, domain)
+		resp, err := certifyingService.Certify(context.Background(), req)
+		require.NoError(t, err)
+		signature := decode(t, resp)
+
+		digest, err := domain.HashFn()(common.TaskResponse{TaskIndex: 1, ReferenceBlockNumber: 100, Data: resp.Data})
+		require.NoError(t, err)
+		valid, err := signature.Verify(keyPair.GetPubKeyG2(), digest)
+		require.NoError(t, err)
+		assert.True(t, valid)
+
+		// the signature can't be replayed for another chain or task
+		otherChain := common.SigningDomain{ChainId: big.NewInt(1), VerifierAddress: domain.VerifierAddress}
+		for _, digest := range [][32]byte{
+			otherChain.Digest(1, 100, resp.Data),
+			domain.Digest(2, 100, resp.Data),
+			domain.Digest(1, 101, resp.Data),
+			[32]byte(crypto.Keccak256(resp.Data)),
+		} {
+			valid, err := signature.Verify(keyPair.GetPubKeyG2(), digest)
+			require.NoError(t, err)
+			assert.False(t, valid)
+		}
+	})
+
+	t.Run("legacy digest", func(t *testing.T) {
+		certifyingService := service.NewCertifyingService(keyPair, echo, common.SigningDomain{}, service.WithLegacyDigest())
+		resp, err := certifyingService.Certify(context.Background(), req)
+		require.NoError(t, err)
+
+		digest, err := common.Keccak256HashFn(common.TaskResponse{TaskIndex: 1, ReferenceBlockNumber: 100, Data: resp.Data})
+		require.NoError(t, err)
+		valid, err := decode(t, resp).Verify(keyPair.GetPubKeyG2(), digest)
+		require.NoError(t, err)
+		assert.True(t, valid)
+	})
+
+	t.Run("nothing is signed without a signing domain", func(t *testing.T) {
+		certifyingService := service.NewCertifyingService(keyPair, echo, common.SigningDomain{})
+		_, err := certifyingService.Certify(context.Background(), req)
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+	})
+}
+
+func TestCertifyingServiceErrors(t *testing.T) {
+	keyPair, err := bls.NewKeyPairFromString("0x1")
+	require.NoError(t, err)
+
+	for _, test := range []struct {
+		name       string
+		err        error
+		code       codes.Code
+		reason     string
+		retryDelay time.Duration
+	}{
+		{"unclassified", errors.New("bad data"), codes.InvalidArgument, "INVALID_INPUT", 0},
+		{"invalid input", fmt.Errorf("%w: data too short", service.ErrInvalidInput), codes.InvalidArgument, "INVALID_INPUT", 0},
+		{"unavailable", fmt.Errorf("%w: rpc down", service.ErrUnavailable), codes.Unavailable, "UNAVAILABLE", 0},
+		{"rate limited", service.WithRetryDelay(service.ErrRateLimited, time.Second), codes.ResourceExhausted, "RATE_LIMITED", time.Second},
+		{"internal", fmt.Errorf("%w: out of disk", service.ErrInternal), codes.Internal, "INTERNAL", 0},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			certifyingService := service.NewCertifyingService(keyPair, func(context.Context, *v1.CertifyRequest) ([]byte, error) {
+				return nil, test.err
+			}, testDomain)
+			_, err := certifyingService.Certify(context.Background(), &v1.CertifyRequest{Data: []byte("data")})
+			st := status.Convert(err)
+			assert.Equal(t, test.code, st.Code())
+
+			var errorInfo *errdetails.ErrorInfo
+			var retryInfo *errdetails.RetryInfo
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					errorInfo = detail
+				case *errdetails.RetryInfo:
+					retryInfo = detail
+				}
+			}
+			require.NotNil(t, errorInfo)
+			assert.Equal(t, test.reason, errorInfo.Reason)
+			assert.Equal(t, service.ErrorDomain, errorInfo.Domain)
+			if test.retryDelay == 0 {
+				assert.Nil(t, retryInfo)
+			} else {
+				require.NotNil(t, retryInfo)
+				assert.Equal(t, test.retryDelay, retryInfo.RetryDelay.AsDuration())
+			}
+		})
+	}
+}
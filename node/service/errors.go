@@ -0,0 +1,75 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// ErrorDomain is the domain of the google.rpc.ErrorInfo details of certifier errors
+const ErrorDomain = "teal.node"
+
+// Certifiers wrap these errors to tell the aggregator why a request failed. Errors
+// wrapping none of them are reported as invalid input.
+var (
+	// ErrInvalidInput means the request can't be certified, retrying it won't help
+	ErrInvalidInput = errors.New("invalid input")
+	// ErrUnavailable means a dependency of the certifier, like an RPC endpoint, is
+	// temporarily unavailable
+	ErrUnavailable = errors.New("temporarily unavailable")
+	// ErrRateLimited means the certifier or one of its dependencies is overloaded
+	ErrRateLimited = errors.New("rate limited")
+	// ErrInternal means the certifier failed for reasons unrelated to the request
+	ErrInternal = errors.New("internal error")
+)
+
+// retryDelayError suggests when to retry the request that failed with err
+type retryDelayError struct {
+	err   error
+	delay time.Duration
+}
+
+func (e *retryDelayError) Error() string { return e.err.Error() }
+func (e *retryDelayError) Unwrap() error { return e.err }
+
+// WithRetryDelay tells the aggregator not to retry the request that failed with err
+// before delay passed, it is meant for ErrUnavailable and ErrRateLimited errors
+func WithRetryDelay(err error, delay time.Duration) error {
+	return &retryDelayError{err: err, delay: delay}
+}
+
+// classifyCertifierError returns the gRPC code and the ErrorInfo reason of an error of
+// a certifier
+func classifyCertifierError(err error) (codes.Code, string) {
+	switch {
+	case errors.Is(err, ErrUnavailable):
+		return codes.Unavailable, "UNAVAILABLE"
+	case errors.Is(err, ErrRateLimited):
+		return codes.ResourceExhausted, "RATE_LIMITED"
+	case errors.Is(err, ErrInternal):
+		return codes.Internal, "INTERNAL"
+	}
+	return codes.InvalidArgument, "INVALID_INPUT"
+}
+
+// certifierStatus converts an error of a certifier to a gRPC status, with an ErrorInfo
+// detail naming the error and a RetryInfo detail if a retry delay was set
+func certifierStatus(err error) *status.Status {
+	code, reason := classifyCertifierError(err)
+
+	st := status.New(code, err.Error())
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain}}
+	var retryDelay *retryDelayError
+	if errors.As(err, &retryDelay) {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(retryDelay.delay)})
+	}
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigensdk-go/types"
+
+	v1 "github.com/Layr-Labs/teal/api/service/v1"
+	"github.com/Layr-Labs/teal/common"
+)
+
+// GetInfo returns the keys the node signs with, the task types it advertises and its version
+func (s *CertifyingService) GetInfo(ctx context.Context, req *v1.GetInfoRequest) (*v1.GetInfoResponse, error) {
+	operatorId := types.OperatorIdFromKeyPair(s.keyPair)
+	return &v1.GetInfoResponse{
+		BlsPubkeyG1: s.keyPair.GetPubKeyG1().Serialize(),
+		BlsPubkeyG2: s.keyPair.GetPubKeyG2().Serialize(),
+		OperatorId:  operatorId[:],
+		TaskTypes:   s.taskTypes,
+		Version:     common.BuildVersion(),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/status"
+)
+
+// MetricsNamespace prefixes the names of the node's metrics
+const MetricsNamespace = "teal_node"
+
+// UnknownTaskType labels the metrics of nodes that don't advertise their task types
+const UnknownTaskType = "unknown"
+
+// Metrics are the Prometheus metrics of a CertifyingService, all labeled by task_type
+type Metrics struct {
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	certifierErrors *prometheus.CounterVec
+	inFlight        *prometheus.GaugeVec
+	signingDuration *prometheus.HistogramVec
+}
+
+// NewMetrics creates the metrics of a CertifyingService and registers them with reg
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	return &Metrics{
+		requests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "certify_requests_total",
+				Help:      "Number of certify requests by the gRPC code they were answered with",
+			},
+			[]string{"task_type", "code"},
+		),
+		requestDuration: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: MetricsNamespace,
+				Name:      "certify_request_duration_seconds",
+				Help:      "Duration of certify requests in seconds by the gRPC code they were answered with",
+				Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+			},
+			[]string{"task_type", "code"},
+		),
+		certifierErrors: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: MetricsNamespace,
+				Name:      "certify_errors_total",
+				Help:      "Number of certify requests that were rejected, by the class of the rejection",
+			},
+			[]string{"task_type", "class"},
+		),
+		inFlight: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: MetricsNamespace,
+				Name:      "certify_requests_in_flight",
+				Help:      "Number of certify requests being served",
+			},
+			[]string{"task_type"},
+		),
+		signingDuration: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: MetricsNamespace,
+				Name:      "signing_duration_seconds",
+				Help:      "Duration of signing responses in seconds, including slashing protection",
+				Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 12),
+			},
+			[]string{"task_type"},
+		),
+	}
+}
+
+// requestStarted counts a request as in flight until the returned function is called
+func (m *Metrics) requestStarted(taskType string) func() {
+	if m == nil {
+		return func() {}
+	}
+	inFlight := m.inFlight.WithLabelValues(taskType)
+	inFlight.Inc()
+	return inFlight.Dec
+}
+
+// observeRequest records a request that was answered with err after duration, class is
+// the reason it was rejected for if err is not nil
+func (m *Metrics) observeRequest(taskType string, duration time.Duration, err error, class string) {
+	if m == nil {
+		return
+	}
+	code := status.Code(err).String()
+	m.requests.WithLabelValues(taskType, code).Inc()
+	m.requestDuration.WithLabelValues(taskType, code).Observe(duration.Seconds())
+	if err != nil {
+		m.certifierErrors.WithLabelValues(taskType, class).Inc()
+	}
+}
+
+func (m *Metrics) observeSigning(taskType string, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.signingDuration.WithLabelValues(taskType).Observe(duration.Seconds())
+}